@@ -0,0 +1,15 @@
+// Пакет example демонстрирует коллекцию, сгенерированную soagen.
+package example
+
+import "time"
+
+//go:generate go run github.com/iv-menshenin/lyceum/cmd/soagen -type Particle
+
+// Particle пример структуры, для которой генерируется коллекция ParticleCollection.
+type Particle struct {
+	X, Y float64
+	Mass float32
+	Name string
+	Born time.Time
+	tags []string
+}
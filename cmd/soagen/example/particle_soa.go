@@ -0,0 +1,256 @@
+// Code generated by soagen. DO NOT EDIT.
+
+package example
+
+import (
+	"iter"
+	"time"
+)
+
+// ParticleCollection хранит элементы Particle по столбцам: каждое поле в собственном слайсе.
+// Адреса, полученные из методов *At, остаются действительными до следующего вызова Push.
+type ParticleCollection struct {
+	n       int
+	colX    []float64
+	colY    []float64
+	colMass []float32
+	colName []string
+	colBorn []time.Time
+	coltags [][]string
+}
+
+// NewParticleCollection создает пустую коллекцию.
+func NewParticleCollection() *ParticleCollection {
+	return &ParticleCollection{}
+}
+
+// Len всегда возвращает актуальное кол-во хранимых элементов.
+func (c *ParticleCollection) Len() int {
+	return c.n
+}
+
+// Push сохраняет новый элемент в коллекции с индексом равным текущему Len и возвращает этот индекс.
+func (c *ParticleCollection) Push(v Particle) int {
+	c.colX = append(c.colX, v.X)
+	c.colY = append(c.colY, v.Y)
+	c.colMass = append(c.colMass, v.Mass)
+	c.colName = append(c.colName, v.Name)
+	c.colBorn = append(c.colBorn, v.Born)
+	c.coltags = append(c.coltags, v.tags)
+	c.n++
+	return c.n - 1
+}
+
+// Get собирает элемент с индексом i из столбцов. Если элемента нет, вторым значением возвращается false.
+func (c *ParticleCollection) Get(i int) (Particle, bool) {
+	var v Particle
+	if uint(i) >= uint(c.n) {
+		return v, false
+	}
+	v.X = c.colX[i]
+	v.Y = c.colY[i]
+	v.Mass = c.colMass[i]
+	v.Name = c.colName[i]
+	v.Born = c.colBorn[i]
+	v.tags = c.coltags[i]
+	return v, true
+}
+
+// Set заменяет элемент с индексом i. Если элемента нет, возвращается false.
+func (c *ParticleCollection) Set(i int, v Particle) bool {
+	if uint(i) >= uint(c.n) {
+		return false
+	}
+	c.colX[i] = v.X
+	c.colY[i] = v.Y
+	c.colMass[i] = v.Mass
+	c.colName[i] = v.Name
+	c.colBorn[i] = v.Born
+	c.coltags[i] = v.tags
+	return true
+}
+
+// Delete удаляет элемент из коллекции. Если это не последний элемент, то его индекс занимает последний элемент.
+func (c *ParticleCollection) Delete(i int) {
+	if uint(i) >= uint(c.n) {
+		return
+	}
+	last := c.n - 1
+	c.colX[i] = c.colX[last]
+	c.colY[i] = c.colY[last]
+	c.colMass[i] = c.colMass[last]
+	c.colName[i] = c.colName[last]
+	c.colBorn[i] = c.colBorn[last]
+	c.coltags[i] = c.coltags[last]
+	c.truncate(last)
+}
+
+// Pop позволяет вернуть последний элемент с удалением его из хранилища.
+func (c *ParticleCollection) Pop() Particle {
+	v, ok := c.Get(c.n - 1)
+	if ok {
+		c.truncate(c.n - 1)
+	}
+	return v
+}
+
+// truncate отрезает хвост всех столбцов до n элементов, обнуляя освободившиеся ячейки.
+func (c *ParticleCollection) truncate(n int) {
+	clear(c.colX[n:])
+	c.colX = c.colX[:n]
+	clear(c.colY[n:])
+	c.colY = c.colY[:n]
+	clear(c.colMass[n:])
+	c.colMass = c.colMass[:n]
+	clear(c.colName[n:])
+	c.colName = c.colName[:n]
+	clear(c.colBorn[n:])
+	c.colBorn = c.colBorn[:n]
+	clear(c.coltags[n:])
+	c.coltags = c.coltags[:n]
+	c.n = n
+}
+
+// XAt возвращает адрес значения поля X элемента с индексом i или nil, если элемента нет.
+func (c *ParticleCollection) XAt(i int) *float64 {
+	if uint(i) >= uint(c.n) {
+		return nil
+	}
+	return &c.colX[i]
+}
+
+// XColumn возвращает столбец значений поля X, индексы которого совпадают с индексами элементов.
+func (c *ParticleCollection) XColumn() []float64 {
+	return c.colX
+}
+
+// XAll возвращает итератор по индексам и адресам значений поля X.
+func (c *ParticleCollection) XAll() iter.Seq2[int, *float64] {
+	return func(yield func(int, *float64) bool) {
+		for i := range c.colX {
+			if !yield(i, &c.colX[i]) {
+				return
+			}
+		}
+	}
+}
+
+// YAt возвращает адрес значения поля Y элемента с индексом i или nil, если элемента нет.
+func (c *ParticleCollection) YAt(i int) *float64 {
+	if uint(i) >= uint(c.n) {
+		return nil
+	}
+	return &c.colY[i]
+}
+
+// YColumn возвращает столбец значений поля Y, индексы которого совпадают с индексами элементов.
+func (c *ParticleCollection) YColumn() []float64 {
+	return c.colY
+}
+
+// YAll возвращает итератор по индексам и адресам значений поля Y.
+func (c *ParticleCollection) YAll() iter.Seq2[int, *float64] {
+	return func(yield func(int, *float64) bool) {
+		for i := range c.colY {
+			if !yield(i, &c.colY[i]) {
+				return
+			}
+		}
+	}
+}
+
+// MassAt возвращает адрес значения поля Mass элемента с индексом i или nil, если элемента нет.
+func (c *ParticleCollection) MassAt(i int) *float32 {
+	if uint(i) >= uint(c.n) {
+		return nil
+	}
+	return &c.colMass[i]
+}
+
+// MassColumn возвращает столбец значений поля Mass, индексы которого совпадают с индексами элементов.
+func (c *ParticleCollection) MassColumn() []float32 {
+	return c.colMass
+}
+
+// MassAll возвращает итератор по индексам и адресам значений поля Mass.
+func (c *ParticleCollection) MassAll() iter.Seq2[int, *float32] {
+	return func(yield func(int, *float32) bool) {
+		for i := range c.colMass {
+			if !yield(i, &c.colMass[i]) {
+				return
+			}
+		}
+	}
+}
+
+// NameAt возвращает адрес значения поля Name элемента с индексом i или nil, если элемента нет.
+func (c *ParticleCollection) NameAt(i int) *string {
+	if uint(i) >= uint(c.n) {
+		return nil
+	}
+	return &c.colName[i]
+}
+
+// NameColumn возвращает столбец значений поля Name, индексы которого совпадают с индексами элементов.
+func (c *ParticleCollection) NameColumn() []string {
+	return c.colName
+}
+
+// NameAll возвращает итератор по индексам и адресам значений поля Name.
+func (c *ParticleCollection) NameAll() iter.Seq2[int, *string] {
+	return func(yield func(int, *string) bool) {
+		for i := range c.colName {
+			if !yield(i, &c.colName[i]) {
+				return
+			}
+		}
+	}
+}
+
+// BornAt возвращает адрес значения поля Born элемента с индексом i или nil, если элемента нет.
+func (c *ParticleCollection) BornAt(i int) *time.Time {
+	if uint(i) >= uint(c.n) {
+		return nil
+	}
+	return &c.colBorn[i]
+}
+
+// BornColumn возвращает столбец значений поля Born, индексы которого совпадают с индексами элементов.
+func (c *ParticleCollection) BornColumn() []time.Time {
+	return c.colBorn
+}
+
+// BornAll возвращает итератор по индексам и адресам значений поля Born.
+func (c *ParticleCollection) BornAll() iter.Seq2[int, *time.Time] {
+	return func(yield func(int, *time.Time) bool) {
+		for i := range c.colBorn {
+			if !yield(i, &c.colBorn[i]) {
+				return
+			}
+		}
+	}
+}
+
+// tagsAt возвращает адрес значения поля tags элемента с индексом i или nil, если элемента нет.
+func (c *ParticleCollection) tagsAt(i int) *[]string {
+	if uint(i) >= uint(c.n) {
+		return nil
+	}
+	return &c.coltags[i]
+}
+
+// tagsColumn возвращает столбец значений поля tags, индексы которого совпадают с индексами элементов.
+func (c *ParticleCollection) tagsColumn() [][]string {
+	return c.coltags
+}
+
+// tagsAll возвращает итератор по индексам и адресам значений поля tags.
+func (c *ParticleCollection) tagsAll() iter.Seq2[int, *[]string] {
+	return func(yield func(int, *[]string) bool) {
+		for i := range c.coltags {
+			if !yield(i, &c.coltags[i]) {
+				return
+			}
+		}
+	}
+}
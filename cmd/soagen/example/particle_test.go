@@ -0,0 +1,92 @@
+package example
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParticleCollection(t *testing.T) {
+	t.Parallel()
+	t.Run("contract", func(t *testing.T) {
+		t.Parallel()
+
+		var c = NewParticleCollection()
+		require.Equal(t, 0, c.Push(Particle{X: 1, Name: "foo", tags: []string{"a"}}))
+		require.Equal(t, 1, c.Push(Particle{X: 2, Name: "bar"}))
+		require.Equal(t, 2, c.Push(Particle{X: 3, Name: "baz"}))
+		require.Equal(t, 3, c.Len())
+
+		v, ok := c.Get(0)
+		require.True(t, ok)
+		require.Equal(t, Particle{X: 1, Name: "foo", tags: []string{"a"}}, v)
+		_, ok = c.Get(3)
+		require.False(t, ok)
+
+		c.Delete(0)
+		require.Equal(t, 2, c.Len())
+		v, _ = c.Get(0)
+		require.Equal(t, "baz", v.Name)
+		require.Equal(t, "bar", c.Pop().Name)
+		require.Equal(t, "baz", c.Pop().Name)
+		require.Zero(t, c.Len())
+		require.Equal(t, Particle{}, c.Pop())
+	})
+	t.Run("columns", func(t *testing.T) {
+		t.Parallel()
+
+		var c = NewParticleCollection()
+		for n := 0; n < 1000; n++ {
+			c.Push(Particle{X: float64(n), Mass: 1})
+		}
+		*c.MassAt(10) = 2
+		require.Nil(t, c.MassAt(1000))
+		require.True(t, c.Set(11, Particle{X: 11, Mass: 3}))
+		require.False(t, c.Set(1000, Particle{}))
+
+		var sum float32
+		for _, m := range c.MassColumn() {
+			sum += m
+		}
+		require.Equal(t, float32(1003), sum)
+
+		var seen int
+		for i, x := range c.XAll() {
+			require.Equal(t, float64(i), *x)
+			*x = 0
+			seen++
+		}
+		require.Equal(t, 1000, seen)
+		v, _ := c.Get(999)
+		require.Zero(t, v.X)
+	})
+}
+
+func BenchmarkParticleScan(b *testing.B) {
+	var (
+		soa = NewParticleCollection()
+		aos = make([]Particle, 0, 1_000_000)
+	)
+	for n := 0; n < 1_000_000; n++ {
+		soa.Push(Particle{X: float64(n), Mass: 1})
+		aos = append(aos, Particle{X: float64(n), Mass: 1})
+	}
+	b.Run("SoA", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			var sum float32
+			for _, m := range soa.MassColumn() {
+				sum += m
+			}
+		}
+	})
+	b.Run("AoS", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			var sum float32
+			for i := range aos {
+				sum += aos[i].Mass
+			}
+		}
+	})
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/printer"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+type (
+	model struct {
+		Package string
+		Type    string
+		Name    string
+		Imports []string
+		Fields  []field
+	}
+	field struct {
+		Name   string // имя поля в структуре
+		Column string // имя столбца в сгенерированном типе
+		Type   string
+	}
+)
+
+// generate возвращает отформатированный исходный код коллекции name для структуры spec из файла file.
+func generate(file *ast.File, spec *ast.TypeSpec, name string) ([]byte, error) {
+	if spec.TypeParams != nil {
+		return nil, fmt.Errorf("generic type %s is not supported", spec.Name.Name)
+	}
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not a struct", spec.Name.Name)
+	}
+
+	m := model{
+		Package: file.Name.Name,
+		Type:    spec.Name.Name,
+		Name:    name,
+	}
+	used := make(map[string]struct{})
+	for _, f := range st.Fields.List {
+		typ, err := exprString(f.Type)
+		if err != nil {
+			return nil, err
+		}
+		collectPackages(f.Type, used)
+		names := f.Names
+		if len(names) == 0 {
+			// встроенное поле называется по имени типа
+			names = []*ast.Ident{ast.NewIdent(embeddedName(f.Type))}
+		}
+		for _, n := range names {
+			if n.Name == "_" {
+				continue
+			}
+			m.Fields = append(m.Fields, field{Name: n.Name, Column: "col" + n.Name, Type: typ})
+		}
+	}
+	if len(m.Fields) == 0 {
+		return nil, fmt.Errorf("struct %s has no fields", spec.Name.Name)
+	}
+	m.Imports = imports(file, used)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, m); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+func exprString(e ast.Expr) (string, error) {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), e); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func embeddedName(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	case *ast.IndexExpr:
+		return embeddedName(t.X)
+	case *ast.IndexListExpr:
+		return embeddedName(t.X)
+	}
+	return ""
+}
+
+// collectPackages собирает имена пакетов, на которые ссылаются типы полей.
+func collectPackages(e ast.Expr, used map[string]struct{}) {
+	ast.Inspect(e, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+}
+
+// imports возвращает импорты файла, которые нужны для типов полей, а также пакет iter для итераторов по столбцам.
+func imports(file *ast.File, used map[string]struct{}) []string {
+	var res = []string{strconv.Quote("iter")}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if _, ok := used[name]; !ok || path == "iter" {
+			continue
+		}
+		if spec.Name != nil {
+			res = append(res, spec.Name.Name+" "+spec.Path.Value)
+			continue
+		}
+		res = append(res, spec.Path.Value)
+	}
+	slices.Sort(res)
+	return res
+}
+
+var tmpl = template.Must(template.New("soa").Parse(`// Code generated by soagen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .Imports }}
+	{{ . }}
+{{- end }}
+)
+
+// {{ .Name }} хранит элементы {{ .Type }} по столбцам: каждое поле в собственном слайсе.
+// Адреса, полученные из методов *At, остаются действительными до следующего вызова Push.
+type {{ .Name }} struct {
+	n int
+{{- range .Fields }}
+	{{ .Column }} []{{ .Type }}
+{{- end }}
+}
+
+// New{{ .Name }} создает пустую коллекцию.
+func New{{ .Name }}() *{{ .Name }} {
+	return &{{ .Name }}{}
+}
+
+// Len всегда возвращает актуальное кол-во хранимых элементов.
+func (c *{{ .Name }}) Len() int {
+	return c.n
+}
+
+// Push сохраняет новый элемент в коллекции с индексом равным текущему Len и возвращает этот индекс.
+func (c *{{ .Name }}) Push(v {{ .Type }}) int {
+{{- range .Fields }}
+	c.{{ .Column }} = append(c.{{ .Column }}, v.{{ .Name }})
+{{- end }}
+	c.n++
+	return c.n - 1
+}
+
+// Get собирает элемент с индексом i из столбцов. Если элемента нет, вторым значением возвращается false.
+func (c *{{ .Name }}) Get(i int) ({{ .Type }}, bool) {
+	var v {{ .Type }}
+	if uint(i) >= uint(c.n) {
+		return v, false
+	}
+{{- range .Fields }}
+	v.{{ .Name }} = c.{{ .Column }}[i]
+{{- end }}
+	return v, true
+}
+
+// Set заменяет элемент с индексом i. Если элемента нет, возвращается false.
+func (c *{{ .Name }}) Set(i int, v {{ .Type }}) bool {
+	if uint(i) >= uint(c.n) {
+		return false
+	}
+{{- range .Fields }}
+	c.{{ .Column }}[i] = v.{{ .Name }}
+{{- end }}
+	return true
+}
+
+// Delete удаляет элемент из коллекции. Если это не последний элемент, то его индекс занимает последний элемент.
+func (c *{{ .Name }}) Delete(i int) {
+	if uint(i) >= uint(c.n) {
+		return
+	}
+	last := c.n - 1
+{{- range .Fields }}
+	c.{{ .Column }}[i] = c.{{ .Column }}[last]
+{{- end }}
+	c.truncate(last)
+}
+
+// Pop позволяет вернуть последний элемент с удалением его из хранилища.
+func (c *{{ .Name }}) Pop() {{ .Type }} {
+	v, ok := c.Get(c.n - 1)
+	if ok {
+		c.truncate(c.n - 1)
+	}
+	return v
+}
+
+// truncate отрезает хвост всех столбцов до n элементов, обнуляя освободившиеся ячейки.
+func (c *{{ .Name }}) truncate(n int) {
+{{- range .Fields }}
+	clear(c.{{ .Column }}[n:])
+	c.{{ .Column }} = c.{{ .Column }}[:n]
+{{- end }}
+	c.n = n
+}
+{{ range .Fields }}
+// {{ .Name }}At возвращает адрес значения поля {{ .Name }} элемента с индексом i или nil, если элемента нет.
+func (c *{{ $.Name }}) {{ .Name }}At(i int) *{{ .Type }} {
+	if uint(i) >= uint(c.n) {
+		return nil
+	}
+	return &c.{{ .Column }}[i]
+}
+
+// {{ .Name }}Column возвращает столбец значений поля {{ .Name }}, индексы которого совпадают с индексами элементов.
+func (c *{{ $.Name }}) {{ .Name }}Column() []{{ .Type }} {
+	return c.{{ .Column }}
+}
+
+// {{ .Name }}All возвращает итератор по индексам и адресам значений поля {{ .Name }}.
+func (c *{{ $.Name }}) {{ .Name }}All() iter.Seq2[int, *{{ .Type }}] {
+	return func(yield func(int, *{{ .Type }}) bool) {
+		for i := range c.{{ .Column }} {
+			if !yield(i, &c.{{ .Column }}[i]) {
+				return
+			}
+		}
+	}
+}
+{{ end -}}
+`))
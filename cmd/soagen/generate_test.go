@@ -0,0 +1,69 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("example_up_to_date", func(t *testing.T) {
+		file, spec, err := findType("example", "Particle")
+		require.NoError(t, err)
+		got, err := generate(file, spec, "ParticleCollection")
+		require.NoError(t, err)
+		want, err := os.ReadFile("example/particle_soa.go")
+		require.NoError(t, err)
+		require.Equal(t, string(want), string(got), "run go generate ./cmd/soagen/example")
+	})
+	t.Run("not_found", func(t *testing.T) {
+		_, _, err := findType("example", "Unknown")
+		require.Error(t, err)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		const src = `package p
+
+import (
+	"io"
+	str "strings"
+)
+
+type (
+	notStruct int
+	generic[T any] struct{ v T }
+	empty struct{ _ int }
+	embedded struct {
+		io.Reader
+		*str.Builder
+		a, b int
+	}
+)
+`
+		file, err := parser.ParseFile(token.NewFileSet(), "p.go", src, 0)
+		require.NoError(t, err)
+		for _, name := range []string{"notStruct", "generic", "empty"} {
+			_, err = generate(file, lookupType(file, name), "c")
+			require.Error(t, err, name)
+		}
+
+		got, err := generate(file, lookupType(file, "embedded"), "embeddedCollection")
+		require.NoError(t, err)
+		require.Contains(t, string(got), "\t\"io\"\n")
+		require.Contains(t, string(got), "\tstr \"strings\"\n")
+		require.Contains(t, string(got), "colReader  []io.Reader")
+		require.Contains(t, string(got), "func (c *embeddedCollection) BuilderAt(i int) **str.Builder")
+		_, err = parser.ParseFile(token.NewFileSet(), "out.go", got, 0)
+		require.NoError(t, err)
+	})
+}
+
+func TestOutputPath(t *testing.T) {
+	abs := filepath.Join(t.TempDir(), "out_soa.go")
+	require.Equal(t, abs, outputPath("example", abs))
+	require.Equal(t, filepath.Join("example", "out_soa.go"), outputPath("example", "out_soa.go"))
+	require.Equal(t, "out_soa.go", outputPath(".", "out_soa.go"))
+}
@@ -0,0 +1,108 @@
+// Команда soagen генерирует для заданной структуры коллекцию с хранением по столбцам (struct-of-arrays):
+// каждое поле структуры хранится в собственном слайсе, что позволяет быстро перебирать одно поле без чтения остальных.
+//
+// Сгенерированный тип повторяет контракт collection.Collection (Len/Push/Get/Delete/Pop с переносом последнего элемента
+// на место удаленного), но Push возвращает индекс, а Get копию элемента, так как элемент целиком нигде не хранится.
+// Для каждого поля генерируются методы <Поле>At (адрес значения в столбце), <Поле>Column (весь столбец) и
+// <Поле>All (итератор по столбцу).
+//
+// Использование:
+//
+//	//go:generate go run github.com/iv-menshenin/lyceum/cmd/soagen -type Particle
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		typeName = flag.String("type", "", "имя структуры, для которой генерируется коллекция")
+		name     = flag.String("name", "", "имя генерируемого типа, по умолчанию <type>Collection")
+		output   = flag.String("output", "", "имя выходного файла относительно каталога пакета или абсолютный путь, по умолчанию <type>_soa.go")
+	)
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("soagen: ")
+
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *name == "" {
+		*name = *typeName + "Collection"
+	}
+	if *output == "" {
+		*output = strings.ToLower(*typeName) + "_soa.go"
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	file, spec, err := findType(dir, *typeName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(file, spec, *name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile(outputPath(dir, *output), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// outputPath возвращает путь выходного файла: относительное имя отсчитывается от каталога пакета dir,
+// а абсолютное используется как есть.
+func outputPath(dir, output string) string {
+	if filepath.IsAbs(output) {
+		return output
+	}
+	return filepath.Join(dir, output)
+}
+
+// findType ищет объявление типа typeName среди файлов пакета в каталоге dir.
+func findType(dir, typeName string) (*ast.File, *ast.TypeSpec, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+	fset := token.NewFileSet()
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, nil, err
+		}
+		if spec := lookupType(file, typeName); spec != nil {
+			return file, spec, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("type %s not found in %s", typeName, dir)
+}
+
+func lookupType(file *ast.File, typeName string) *ast.TypeSpec {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if ts := spec.(*ast.TypeSpec); ts.Name.Name == typeName {
+				return ts
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package collection
+
+import "slices"
+
+// Cap возвращает количество элементов, которое коллекция может вместить без выделения новой памяти.
+func (c *Collection[T]) Cap() int {
+	return len(c.chunks) << c.shift
+}
+
+// Grow резервирует память так, чтобы следующие n вызовов Push не приводили к аллокациям.
+func (c *Collection[T]) Grow(n int) {
+	if n <= 0 {
+		return
+	}
+	c.initLayout()
+	need := (c.n + n + c.mask) >> c.shift
+	if need <= len(c.chunks) {
+		return
+	}
+	c.chunks = slices.Grow(c.chunks, need-len(c.chunks))
+	for len(c.chunks) < need {
+		c.addChunk()
+	}
+}
+
+// PushMany добавляет в конец коллекции сразу несколько элементов. Это заметно быстрее, чем вызывать Push для каждого из них.
+func (c *Collection[T]) PushMany(vs ...T) {
+	c.Grow(len(vs))
+	c.handles.pushN(len(vs))
+	for len(vs) > 0 {
+		k := copy(c.tail(c.n), vs)
+		c.n += k
+		vs = vs[k:]
+	}
+}
+
+// Truncate оставляет в коллекции только первые n элементов. Память страниц сохраняется для повторного использования.
+// Если n не меньше Len, ничего не происходит.
+func (c *Collection[T]) Truncate(n int) {
+	if n < 0 || n >= c.n {
+		return
+	}
+	c.handles.deleteRange(n, c.n)
+	c.truncate(n)
+}
+
+// Reset удаляет все элементы, сохраняя выделенную память. Повторное заполнение коллекции до прежнего размера не требует аллокаций.
+func (c *Collection[T]) Reset() {
+	c.Truncate(0)
+}
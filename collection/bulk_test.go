@@ -0,0 +1,155 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionBulk(t *testing.T) {
+	t.Parallel()
+	t.Run("push_many", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		c.Push(-1)
+		var vs = make([]int, 3*chunkSize)
+		for n := range vs {
+			vs[n] = n
+		}
+		c.PushMany(vs...)
+		require.Equal(t, len(vs)+1, c.Len())
+		require.Equal(t, -1, *c.Get(0))
+		for n := range vs {
+			require.Equal(t, n, *c.Get(n + 1))
+		}
+		c.PushMany()
+		require.Equal(t, len(vs)+1, c.Len())
+	})
+	t.Run("grow", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		c.Grow(chunkSize + 1)
+		require.GreaterOrEqual(t, c.Cap(), chunkSize+1)
+		capacity := c.Cap()
+		for n := 0; n < chunkSize+1; n++ {
+			c.Push(n)
+		}
+		require.Equal(t, capacity, c.Cap())
+		c.Grow(0)
+		require.Equal(t, capacity, c.Cap())
+	})
+	t.Run("truncate", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[string]()
+		c.PushMany("foo", "bar", "baz")
+		_, h := c.PushHandle("qux")
+		c.Truncate(5)
+		require.Equal(t, 4, c.Len())
+		c.Truncate(1)
+		require.Equal(t, 1, c.Len())
+		require.Equal(t, "foo", *c.Get(0))
+		require.Nil(t, c.Get(1))
+		require.Nil(t, c.GetByHandle(h))
+		// освободившиеся ячейки обнулены
+		require.Equal(t, "", *c.Push(""))
+		require.Equal(t, "", c.Pop())
+	})
+	t.Run("reset", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[string]()
+		c.PushMany("foo", "bar")
+		c.Reset()
+		require.Zero(t, c.Len())
+		require.Nil(t, c.Get(0))
+		c.Push("baz")
+		require.Equal(t, "baz", *c.Get(0))
+	})
+}
+
+func TestCollectionReusable(t *testing.T) {
+	t.Run("10", func(t *testing.T) {
+		testNoAllocations(t, 10)
+	})
+	t.Run("100_000", func(t *testing.T) {
+		testNoAllocations(t, 100_000)
+	})
+	t.Run("1_000_000", func(t *testing.T) {
+		testNoAllocations(t, 1_000_000)
+	})
+	t.Run("grow", func(t *testing.T) {
+		var c = New[int]()
+		// AllocsPerRun выполняет функцию дважды: прогревочный и основной прогон
+		c.Grow(2 * 100_000)
+		a := testing.AllocsPerRun(1, func() {
+			for n := 0; n < 100_000; n++ {
+				c.Push(n)
+			}
+		})
+		require.Equal(t, float64(0), a)
+	})
+}
+
+func testNoAllocations(t *testing.T, count int) {
+	t.Helper()
+
+	type T struct {
+		n int
+		s string
+	}
+	var c = New[T]()
+	var vs = make([]T, count)
+	for n := 0; n < count; n++ {
+		_, _ = c.PushHandle(T{n: n})
+	}
+
+	a := testing.AllocsPerRun(10, func() {
+		c.Reset()
+		for n := 0; n < count; n++ {
+			_, _ = c.PushHandle(T{n: n})
+		}
+		c.Reset()
+		c.PushMany(vs...)
+	})
+	require.Equal(t, float64(0), a)
+}
+
+func BenchmarkCollectionPushMany(b *testing.B) {
+	type Elem struct {
+		s          string
+		a, b, c, d int64
+		n          int
+	}
+	var vs = make([]Elem, 10_000_000)
+	for i := range vs {
+		vs[i] = Elem{a: int64(i), b: -1, s: "Push 10m"}
+	}
+	b.Run("PushMany_10M", func(b *testing.B) {
+		b.ReportAllocs()
+		var c = New[Elem]()
+		for n := 0; n < b.N; n++ {
+			c.PushMany(vs...)
+		}
+	})
+	b.Run("Grow_Push_10M", func(b *testing.B) {
+		b.ReportAllocs()
+		var c = New[Elem]()
+		for n := 0; n < b.N; n++ {
+			c.Grow(len(vs))
+			for i := range vs {
+				c.Push(vs[i])
+			}
+		}
+	})
+	b.Run("Reset_Push_10M", func(b *testing.B) {
+		b.ReportAllocs()
+		var c = New[Elem]()
+		for n := 0; n < b.N; n++ {
+			c.Reset()
+			c.PushMany(vs...)
+		}
+	})
+}
@@ -1,41 +1,181 @@
 package collection
 
+import "unsafe"
+
 // ТРЕБУЕТСЯ: Написать реализацию структуры для хранения данных следуя указанному ниже контракту.
 
+// Элементы хранятся в страницах фиксированного размера: не больше pageBytes байт и не больше chunkSize элементов,
+// так что для маленьких типов страница вмещает chunkSize элементов, а для больших - всего несколько.
+// Страницы никогда не перемещаются в памяти при росте коллекции,
+// поэтому адрес, полученный из Push или Get, остается действительным, пока элемент не удален или не перемещен через Delete.
+//
+// Исключение составляет Snapshot. Снимок разделяет страницы с коллекцией, и первая запись в страницу после него
+// (любым методом коллекции или через адрес из Get) переносит коллекцию на копию этой страницы. Адреса, полученные
+// до Snapshot, после этого устаревают: они продолжают указывать на страницу снимка, и запись по ним изменит снимок,
+// а не коллекцию. Поэтому после Snapshot адреса элементов нужно получить заново через Get.
+const (
+	chunkBits = 12
+	chunkSize = 1 << chunkBits
+	chunkMask = chunkSize - 1
+	pageBytes = 32 << 10
+)
+
 type (
 	// Collection должна быть обобщенной и уметь разного рода типы данных.
 	Collection[T any] struct {
-		// TODO
+		chunks  []chunk[T]
+		n       int
+		handles handles
+		cow     cow
+		layout
+		options
+	}
+
+	// chunk страница с элементами, ее длина всегда равна размеру страницы из layout.
+	chunk[T any] []T
+
+	// layout размер страниц для конкретного типа элементов. Нулевое значение означает, что размер еще не вычислен.
+	layout struct {
+		shift uint // log2 количества элементов на странице
+		mask  int  // количество элементов на странице - 1
 	}
 )
 
+// layoutOf вычисляет размер страницы для элементов типа T: наибольшая степень двойки, при которой страница
+// занимает не больше pageBytes, но не больше chunkSize элементов.
+func layoutOf[T any]() layout {
+	size := unsafe.Sizeof(*new(T))
+	shift := uint(chunkBits)
+	for shift > 0 && size<<shift > pageBytes {
+		shift--
+	}
+	return layout{shift: shift, mask: 1<<shift - 1}
+}
+
+// size возвращает количество элементов на странице.
+func (l layout) size() int {
+	return l.mask + 1
+}
+
 // New конструктор, который будет вызываться в каждом тесте для инициализации объекта.
-func New[T any]() *Collection[T] {
-	// TODO
+// Нулевое значение Collection также готово к работе, опции нужны только для дополнительных настроек.
+func New[T any](opts ...Option) *Collection[T] {
+	var c Collection[T]
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	return &c
 }
 
 // Len всегда возвращает актуальное кол-во хранимых элементов.
 func (c *Collection[T]) Len() int {
-	// TODO
+	return c.n
 }
 
 // Push сохраняет новый элемент в коллекции с индексом равным текущему Len. По сути это замена append стандартным слайсам.
-func (c *Collection[T]) Push(T) *T {
-	// TODO
+// Возвращаемый адрес устаревает после Snapshot и первой последующей записи в страницу элемента.
+func (c *Collection[T]) Push(v T) *T {
+	if c.n>>c.shift == len(c.chunks) {
+		c.addChunk()
+	}
+	p := c.ref(c.n)
+	*p = v
+	c.n++
+	c.handles.push()
+	return p
 }
 
-// Get позволяет получить адрес элемента по его порядковому номеру.
-func (c *Collection[T]) Get(int) *T {
-	// TODO
+// Get позволяет получить адрес элемента по его порядковому номеру. Запись по этому адресу не затрагивает снимки:
+// если страница элемента разделяется со снимком, она предварительно копируется. Как и адрес из Push,
+// возвращаемый адрес устаревает после следующего Snapshot и первой последующей записи в страницу элемента.
+func (c *Collection[T]) Get(i int) *T {
+	if uint(i) >= uint(c.n) {
+		return nil
+	}
+	return c.ref(i)
+}
+
+// Load возвращает копию элемента с индексом i. В отличие от Get, никогда не копирует страницу, разделяемую со снимком,
+// поэтому подходит для чтения после Snapshot.
+func (c *Collection[T]) Load(i int) (T, bool) {
+	if uint(i) >= uint(c.n) {
+		var zero T
+		return zero, false
+	}
+	return *c.at(i), true
 }
 
 // Delete удаляет элемент из коллекции. Если это не последний элемент, то его идентификатор занимается другим элементом.
 // Важно, чтобы после выполнения этого метода идентификаторы элементов все еще были непрерывны.
-func (c *Collection[T]) Delete(int) {
-	// TODO
+func (c *Collection[T]) Delete(i int) {
+	if uint(i) >= uint(c.n) {
+		return
+	}
+	last := c.n - 1
+	if i != last {
+		*c.ref(i) = *c.at(last)
+	}
+	c.handles.delete(i, last)
+	c.dropLast()
+	if i != last {
+		c.moved(last, i)
+	}
 }
 
 // Pop позволяет вернуть последний элемент с удалением его из хранилища.
 func (c *Collection[T]) Pop() T {
-	// TODO
+	if c.n == 0 {
+		var zero T
+		return zero
+	}
+	last := c.n - 1
+	v := *c.at(last)
+	c.handles.delete(last, last)
+	c.dropLast()
+	return v
+}
+
+// at возвращает адрес элемента для чтения без проверки границ.
+func (c *Collection[T]) at(i int) *T {
+	return &c.chunks[i>>c.shift][i&c.mask]
+}
+
+// ref возвращает адрес элемента для записи без проверки границ. Если страница разделяется со снимком, она предварительно копируется.
+func (c *Collection[T]) ref(i int) *T {
+	if c.cow.epoch != 0 {
+		c.own(i >> c.shift)
+	}
+	return c.at(i)
+}
+
+// page возвращает доступную для записи страницу, содержащую элемент i.
+func (c *Collection[T]) page(i int) chunk[T] {
+	if c.cow.epoch != 0 {
+		c.own(i >> c.shift)
+	}
+	return c.chunks[i>>c.shift]
+}
+
+// addChunk добавляет в коллекцию новую страницу.
+func (c *Collection[T]) addChunk() {
+	c.initLayout()
+	c.chunks = append(c.chunks, make(chunk[T], c.size()))
+	c.cow.add()
+}
+
+// initLayout вычисляет размер страниц перед появлением первой из них, чтобы нулевое значение Collection было готово к работе.
+func (c *Collection[T]) initLayout() {
+	if len(c.chunks) == 0 {
+		c.layout = layoutOf[T]()
+	}
+}
+
+// dropLast удаляет последний элемент, обнуляя освободившуюся ячейку, чтобы не удерживать ссылки от сборщика мусора.
+// Страница при этом сохраняется и будет переиспользована следующими вызовами Push.
+func (c *Collection[T]) dropLast() {
+	c.n--
+	if c.owns(c.n >> c.shift) {
+		var zero T
+		*c.at(c.n) = zero
+	}
 }
@@ -160,6 +160,37 @@ func TestCollectionPushPop(t *testing.T) {
 	})
 }
 
+func TestCollectionPointerStability(t *testing.T) {
+	t.Parallel()
+	t.Run("push_grow", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		const elemCount = 100000
+		var ptrs = make([]*int, elemCount)
+		for n := 0; n < elemCount; n++ {
+			ptrs[n] = c.Push(n)
+		}
+		for n := 0; n < elemCount; n++ {
+			require.Same(t, ptrs[n], c.Get(n))
+			require.Equal(t, n, *ptrs[n])
+		}
+	})
+	t.Run("delete_keeps_others", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		p0 := c.Push(0)
+		p1 := c.Push(1)
+		c.Push(2)
+		c.Delete(1)
+		require.Same(t, p0, c.Get(0))
+		// на место удаленного элемента переехал последний, адрес ячейки сохраняется
+		require.Same(t, p1, c.Get(1))
+		require.Equal(t, 2, *p1)
+	})
+}
+
 func BenchmarkCollectionGet_10mln(b *testing.B) {
 	type Elem struct {
 		s          string
@@ -0,0 +1,142 @@
+// Package collectiontest содержит общий набор проверок для реализаций контракта Collection: Push, Get, Delete, Pop и Len.
+// Проверки выполняют программу из операций одновременно над проверяемой коллекцией и эталонным срезом и сравнивают
+// результаты. Программа задается срезом байт, поэтому тот же набор проверок используется нативным фаззингом Go.
+package collectiontest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Collection контракт, которому должна соответствовать проверяемая реализация.
+type Collection[T any] interface {
+	Len() int
+	Push(v T) *T
+	Get(i int) *T
+	Delete(i int)
+	Pop() T
+}
+
+const (
+	opPush = iota
+	opPushMany
+	opDelete
+	opPop
+	opSet
+	opCount
+)
+
+// maxLen ограничивает размер коллекции, чтобы одна программа фаззера выполнялась быстро,
+// но при этом могла пересечь границы нескольких страниц.
+const maxLen = 1 << 15
+
+// Fuzz запускает фаззинг реализации. Функция newCollection создает пустую коллекцию для каждой программы,
+// освобождение ресурсов можно зарегистрировать через t.Cleanup. Функция value возвращает элемент по его
+// порядковому номеру, разные номера должны давать разные элементы.
+// Без флага -fuzz выполняются только программы из встроенного набора и из каталога testdata.
+func Fuzz[T any](f *testing.F, newCollection func(t testing.TB) Collection[T], value func(seq int) T) {
+	f.Helper()
+	for _, program := range seeds {
+		f.Add(program)
+	}
+	f.Fuzz(func(t *testing.T, program []byte) {
+		Check(t, newCollection(t), value, program)
+	})
+}
+
+// seeds начальный набор программ: простые сценарии и переходы через границы страниц.
+var seeds = [][]byte{
+	{},
+	{opPush, 0, opPush, 0, opPush, 0, opDelete, 0, opPop, 0, opPop, 0, opPop, 0},
+	{opPush, 0, opDelete, 255, opDelete, 0, opPop, 0, opSet, 0},
+	{opPushMany, 3, opDelete, 128, opSet, 255, opDelete, 0, opDelete, 255, opPop, 0},
+	{opPushMany | 4*opCount, 255, opDelete, 0, opDelete, 200, opSet, 100, opPop, 0, opPushMany, 10},
+	{opPushMany | 5*opCount, 200, opPop, 0, opPushMany | 3*opCount, 255, opDelete, 64, opPop, 0},
+}
+
+// Check выполняет программу над коллекцией c, которая должна быть пустой, и эталонным срезом.
+// Каждая операция занимает два байта: код операции и ее аргумент.
+func Check[T any](t testing.TB, c Collection[T], value func(seq int) T, program []byte) {
+	t.Helper()
+	require.Zero(t, c.Len())
+
+	var (
+		ref []T
+		seq int
+	)
+	push := func() {
+		seq++
+		v := value(seq)
+		p := c.Push(v)
+		require.NotNil(t, p, "push #%d", seq)
+		require.Equal(t, v, *p, "push #%d", seq)
+		ref = append(ref, v)
+	}
+	for pc := 0; pc < len(program); pc += 2 {
+		op, arg := program[pc], byte(0)
+		if pc+1 < len(program) {
+			arg = program[pc+1]
+		}
+		// индекс в диапазоне [-1, Len], чтобы проверять и выход за границы
+		i := int(arg)*(len(ref)+2)/256 - 1
+
+		switch op % opCount {
+		case opPush:
+			if len(ref) < maxLen {
+				push()
+			}
+		case opPushMany:
+			n := int(arg) << (op / opCount % 6)
+			for ; n > 0 && len(ref) < maxLen; n-- {
+				push()
+			}
+		case opDelete:
+			c.Delete(i)
+			if i < 0 || i >= len(ref) {
+				break
+			}
+			last := len(ref) - 1
+			ref[i] = ref[last]
+			ref = ref[:last]
+			if i < last {
+				require.Equal(t, ref[i], *c.Get(i), "delete %d of %d: element was not relocated", i, last+1)
+			}
+		case opPop:
+			var want T
+			if n := len(ref); n > 0 {
+				want = ref[n-1]
+				ref = ref[:n-1]
+			}
+			require.Equal(t, want, c.Pop(), "pop at %d", len(ref))
+		case opSet:
+			p := c.Get(i)
+			if i < 0 || i >= len(ref) {
+				require.Nil(t, p, "get %d of %d", i, len(ref))
+				break
+			}
+			require.NotNil(t, p, "get %d of %d", i, len(ref))
+			require.Equal(t, ref[i], *p, "get %d of %d", i, len(ref))
+			seq++
+			*p = value(seq)
+			ref[i] = *p
+		}
+		require.Equal(t, len(ref), c.Len(), "len after op %d at %d", op%opCount, pc)
+	}
+	Verify(t, c, ref)
+}
+
+// Verify сравнивает все элементы коллекции c с эталонным срезом ref.
+func Verify[T any](t testing.TB, c Collection[T], ref []T) {
+	t.Helper()
+	require.Equal(t, len(ref), c.Len(), "len")
+	for i := range ref {
+		p := c.Get(i)
+		if p == nil || !assert.ObjectsAreEqual(ref[i], *p) {
+			require.FailNow(t, "element mismatch", "index %d: want %v, got %v", i, ref[i], p)
+		}
+	}
+	require.Nil(t, c.Get(-1), "get before first element")
+	require.Nil(t, c.Get(len(ref)), "get after last element")
+}
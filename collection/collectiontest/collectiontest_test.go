@@ -0,0 +1,53 @@
+package collectiontest
+
+import (
+	"strconv"
+	"testing"
+)
+
+// slice простейшая реализация контракта поверх среза, на которой проверяется сам набор проверок.
+type slice[T any] struct {
+	s []T
+}
+
+func (c *slice[T]) Len() int {
+	return len(c.s)
+}
+
+func (c *slice[T]) Push(v T) *T {
+	c.s = append(c.s, v)
+	return &c.s[len(c.s)-1]
+}
+
+func (c *slice[T]) Get(i int) *T {
+	if i < 0 || i >= len(c.s) {
+		return nil
+	}
+	return &c.s[i]
+}
+
+func (c *slice[T]) Delete(i int) {
+	if i < 0 || i >= len(c.s) {
+		return
+	}
+	last := len(c.s) - 1
+	c.s[i] = c.s[last]
+	c.s = c.s[:last]
+}
+
+func (c *slice[T]) Pop() T {
+	var v T
+	if n := len(c.s); n > 0 {
+		v = c.s[n-1]
+		c.s = c.s[:n-1]
+	}
+	return v
+}
+
+func FuzzSlice(f *testing.F) {
+	Fuzz(f, func(testing.TB) Collection[string] {
+		return &slice[string]{}
+	}, func(seq int) string {
+		return strconv.Itoa(seq)
+	})
+}
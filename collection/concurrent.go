@@ -0,0 +1,152 @@
+package collection
+
+import (
+	"math/bits"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// readShards наибольшее количество независимых блокировок на чтение. Каждый читатель захватывает только одну из них,
+// выбранную случайно при каждом вызове, поэтому конкурентные Get не упираются в общий счетчик читателей одного мьютекса,
+// даже если читают один и тот же элемент. Писатели захватывают все используемые блокировки, поэтому их количество
+// ограничивается числом процессоров (GOMAXPROCS) на момент создания коллекции.
+const readShards = 16
+
+type (
+	// ConcurrentCollection потокобезопасный вариант Collection с тем же контрактом Push/Get/Delete/Pop/Len.
+	// Адрес, полученный из Push или Get, остается стабильным, но чтение и запись по нему не синхронизированы с
+	// конкурентными Delete и Pop. Для безопасного доступа к значению используйте Load и Update.
+	ConcurrentCollection[T any] struct {
+		shards [readShards]rwShard
+		width  int // количество используемых блокировок из shards, степень двойки; 0 означает все
+		n      atomic.Int64
+		c      Collection[T]
+	}
+
+	rwShard struct {
+		sync.RWMutex
+		_ [64 - unsafe.Sizeof(sync.RWMutex{})%64]byte
+	}
+)
+
+// NewConcurrent создает потокобезопасную коллекцию. Обработчики OnMove и OnSwap вызываются под блокировкой на запись,
+// поэтому они не должны обращаться к методам этой же коллекции.
+func NewConcurrent[T any](opts ...Option) *ConcurrentCollection[T] {
+	var c ConcurrentCollection[T]
+	c.width = min(readShards, 1<<bits.Len(uint(runtime.GOMAXPROCS(0)-1)))
+	for _, opt := range opts {
+		opt(&c.c.options)
+	}
+	return &c
+}
+
+// Len возвращает актуальное кол-во хранимых элементов без захвата блокировок.
+func (c *ConcurrentCollection[T]) Len() int {
+	return int(c.n.Load())
+}
+
+// Push сохраняет новый элемент в коллекции с индексом равным текущему Len.
+func (c *ConcurrentCollection[T]) Push(v T) *T {
+	c.lock()
+	defer c.unlock()
+	return c.c.Push(v)
+}
+
+// Get позволяет получить адрес элемента по его порядковому номеру. Если страница элемента разделяется со снимком,
+// она копируется под блокировкой на запись, чтобы запись по выданному адресу не изменила снимок.
+func (c *ConcurrentCollection[T]) Get(i int) *T {
+	s := c.rlock()
+	if uint(i) >= uint(c.c.Len()) {
+		s.RUnlock()
+		return nil
+	}
+	if c.c.owns(i >> c.c.shift) {
+		p := c.c.at(i)
+		s.RUnlock()
+		return p
+	}
+	s.RUnlock()
+	c.lock()
+	defer c.unlock()
+	return c.c.Get(i)
+}
+
+// Load возвращает копию элемента с индексом i. Копирование выполняется под блокировкой, поэтому не конфликтует с писателями.
+func (c *ConcurrentCollection[T]) Load(i int) (T, bool) {
+	s := c.rlock()
+	defer s.RUnlock()
+	if uint(i) >= uint(c.c.Len()) {
+		var zero T
+		return zero, false
+	}
+	// только чтение: копирование страниц, разделяемых со снимком, допустимо лишь под блокировкой на запись
+	return *c.c.at(i), true
+}
+
+// Update вызывает fn для элемента с индексом i под блокировкой на запись. Возвращает false, если элемента нет.
+func (c *ConcurrentCollection[T]) Update(i int, fn func(*T)) bool {
+	c.lock()
+	defer c.unlock()
+	p := c.c.Get(i)
+	if p == nil {
+		return false
+	}
+	fn(p)
+	return true
+}
+
+// Delete удаляет элемент из коллекции, перенося на его место последний элемент.
+func (c *ConcurrentCollection[T]) Delete(i int) {
+	c.lock()
+	defer c.unlock()
+	c.c.Delete(i)
+}
+
+// Pop позволяет вернуть последний элемент с удалением его из хранилища.
+func (c *ConcurrentCollection[T]) Pop() T {
+	c.lock()
+	defer c.unlock()
+	return c.c.Pop()
+}
+
+// Snapshot возвращает неизменяемый снимок коллекции, который можно читать без блокировок одновременно с ее изменением.
+func (c *ConcurrentCollection[T]) Snapshot() *Snapshot[T] {
+	c.lock()
+	defer c.unlock()
+	return c.c.Snapshot()
+}
+
+// rlock захватывает на чтение одну из используемых блокировок. Она выбирается для каждого вызова, а не по индексу
+// элемента, чтобы читатели одного и того же элемента не конкурировали между собой.
+func (c *ConcurrentCollection[T]) rlock() *rwShard {
+	sh := c.used()
+	s := &sh[rand.Uint32()&uint32(len(sh)-1)]
+	s.RLock()
+	return s
+}
+
+func (c *ConcurrentCollection[T]) lock() {
+	sh := c.used()
+	for i := range sh {
+		sh[i].Lock()
+	}
+}
+
+func (c *ConcurrentCollection[T]) unlock() {
+	c.n.Store(int64(c.c.Len()))
+	sh := c.used()
+	for i := range sh {
+		sh[i].Unlock()
+	}
+}
+
+// used возвращает используемые блокировки на чтение.
+func (c *ConcurrentCollection[T]) used() []rwShard {
+	if c.width == 0 {
+		return c.shards[:]
+	}
+	return c.shards[:c.width]
+}
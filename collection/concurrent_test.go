@@ -0,0 +1,261 @@
+package collection
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentCollection(t *testing.T) {
+	t.Parallel()
+	t.Run("contract", func(t *testing.T) {
+		t.Parallel()
+
+		var c = NewConcurrent[string]()
+		require.Equal(t, "foo", *c.Push("foo"))
+		c.Push("bar")
+		c.Push("baz")
+		require.Equal(t, 3, c.Len())
+		c.Delete(0)
+		require.Equal(t, "baz", *c.Get(0))
+		require.Equal(t, "bar", c.Pop())
+		require.Equal(t, 1, c.Len())
+		require.Nil(t, c.Get(1))
+
+		v, ok := c.Load(0)
+		require.True(t, ok)
+		require.Equal(t, "baz", v)
+		_, ok = c.Load(1)
+		require.False(t, ok)
+
+		require.True(t, c.Update(0, func(s *string) { *s = "qux" }))
+		require.False(t, c.Update(1, func(s *string) { *s = "qux" }))
+		require.Equal(t, "qux", *c.Get(0))
+	})
+	t.Run("parallel_push", func(t *testing.T) {
+		t.Parallel()
+
+		const (
+			workers = 8
+			perWork = 10000
+		)
+		var c = NewConcurrent[int]()
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for n := 0; n < perWork; n++ {
+					c.Push(w*perWork + n)
+				}
+			}(w)
+		}
+		wg.Wait()
+
+		require.Equal(t, workers*perWork, c.Len())
+		var got = make([]int, 0, c.Len())
+		for i := 0; i < c.Len(); i++ {
+			v, ok := c.Load(i)
+			require.True(t, ok)
+			got = append(got, v)
+		}
+		sort.Ints(got)
+		for n, v := range got {
+			require.Equal(t, n, v)
+		}
+	})
+	t.Run("readers_and_writers", func(t *testing.T) {
+		t.Parallel()
+
+		type Elem struct {
+			a, b int
+		}
+		var c = NewConcurrent[Elem]()
+		for n := 0; n < 10000; n++ {
+			c.Push(Elem{a: n, b: -n})
+		}
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for n := 0; n < 10000; n++ {
+					v, ok := c.Load((n * 127) % 10000)
+					if ok && v.a != -v.b {
+						t.Errorf("torn read: %+v", v)
+						return
+					}
+				}
+			}(w)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 5000; n++ {
+				c.Delete(n % 7)
+				c.Update(n%11, func(e *Elem) { e.a, e.b = n, -n })
+				c.Push(Elem{a: n, b: -n})
+				if n%2 == 0 {
+					c.Pop()
+				}
+			}
+		}()
+		wg.Wait()
+		require.Equal(t, 7500, c.Len())
+	})
+	t.Run("read_shards", func(t *testing.T) {
+		t.Parallel()
+
+		var c = NewConcurrent[int]()
+		w := len(c.used())
+		require.LessOrEqual(t, w, readShards)
+		require.LessOrEqual(t, min(runtime.GOMAXPROCS(0), readShards), w)
+		require.Zero(t, w&(w-1), "power of two")
+		// нулевое значение использует все блокировки
+		var z ConcurrentCollection[int]
+		require.Len(t, z.used(), readShards)
+		z.Push(1)
+		v, ok := z.Load(0)
+		require.True(t, ok)
+		require.Equal(t, 1, v)
+	})
+	t.Run("readers_of_one_element", func(t *testing.T) {
+		t.Parallel()
+
+		var c ConcurrentCollection[int]
+		c.Push(1)
+		var wg sync.WaitGroup
+		for w := 0; w < 8; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 10000 {
+					if v, ok := c.Load(0); !ok || v != 1 && v != 2 {
+						t.Errorf("load: %d, %v", v, ok)
+						return
+					}
+				}
+			}()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 1000; n++ {
+				c.Update(0, func(v *int) { *v = 3 - *v })
+			}
+		}()
+		wg.Wait()
+	})
+	t.Run("readers_after_snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		var c = NewConcurrent[int]()
+		for n := 0; n < 5*chunkSize; n++ {
+			c.Push(n)
+		}
+		var s *Snapshot[int]
+		for round := 0; round < 8; round++ {
+			s = c.Snapshot()
+			var wg sync.WaitGroup
+			for w := 0; w < 4; w++ {
+				wg.Add(1)
+				go func(w int) {
+					defer wg.Done()
+					for n := 0; n < 5*chunkSize; n++ {
+						i := (n*127 + w) % (5 * chunkSize)
+						if v, ok := c.Load(i); !ok || v != i {
+							t.Errorf("load %d: %d, %v", i, v, ok)
+							return
+						}
+						if w%2 == 0 {
+							continue
+						}
+						if p := c.Get(i); p == nil || *p != i {
+							t.Errorf("get %d: %v", i, p)
+							return
+						}
+					}
+				}(w)
+			}
+			wg.Wait()
+		}
+
+		// адрес из Get доступен для записи и не меняет снимок
+		*c.Get(10) = -1
+		require.Equal(t, 10, *s.Get(10))
+		v, _ := c.Load(10)
+		require.Equal(t, -1, v)
+	})
+}
+
+func BenchmarkConcurrentCollectionGet_10mln(b *testing.B) {
+	type Elem struct {
+		s          string
+		a, b, c, d int64
+		n          int
+	}
+	var c = NewConcurrent[Elem]()
+	const count = 10_000_000
+	c.lock()
+	for n := 0; n < count; n++ {
+		c.c.Push(Elem{n: n})
+	}
+	c.unlock()
+	b.Run("Random", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			var n int
+			for pb.Next() {
+				n++
+				switch n % 5 {
+				case 0:
+					_ = c.Get(count / 2)
+				case 1:
+					_ = c.Get(n % count)
+				case 2:
+					_ = c.Get(count - (n % count) - 1)
+				case 3:
+					_ = c.Get(count - ((n * 127) % count) - 1)
+				case 4:
+					_ = c.Get((n * 127) % count)
+				}
+			}
+		})
+	})
+	b.Run("Load_Random", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			var n int
+			for pb.Next() {
+				n++
+				_, _ = c.Load((n * 127) % count)
+			}
+		})
+	})
+	b.Run("Load_Random_With_Writer", func(b *testing.B) {
+		b.ReportAllocs()
+		var done = make(chan struct{})
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					c.Push(Elem{})
+					c.Pop()
+				}
+			}
+		}()
+		b.RunParallel(func(pb *testing.PB) {
+			var n int
+			for pb.Next() {
+				n++
+				_, _ = c.Load((n * 127) % count)
+			}
+		})
+		close(done)
+	})
+}
@@ -0,0 +1,24 @@
+package collection
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/iv-menshenin/lyceum/collection/collectiontest"
+)
+
+func FuzzCollection(f *testing.F) {
+	collectiontest.Fuzz(f, func(testing.TB) collectiontest.Collection[string] {
+		return New[string]()
+	}, fuzzValue)
+}
+
+func FuzzConcurrentCollection(f *testing.F) {
+	collectiontest.Fuzz(f, func(testing.TB) collectiontest.Collection[string] {
+		return NewConcurrent[string]()
+	}, fuzzValue)
+}
+
+func fuzzValue(seq int) string {
+	return strconv.Itoa(seq)
+}
@@ -0,0 +1,144 @@
+package collection
+
+// Deque двусторонняя очередь с добавлением и удалением элементов с обоих концов за O(1).
+// Как и в Collection, индексы элементов всегда непрерывны: индекс 0 соответствует голове очереди, а Len-1 ее хвосту.
+// Элементы хранятся в тех же страницах фиксированного размера, поэтому адрес, полученный из PushBack, PushFront или Get,
+// остается действительным, пока элемент находится в очереди. Нулевое значение Deque готово к работе.
+type Deque[T any] struct {
+	buf    []chunk[T] // каталог страниц, используемая часть buf[lo:hi] окружена свободным местом с обеих сторон
+	lo, hi int
+	head   int      // позиция головы внутри первой страницы
+	n      int      // количество элементов
+	spare  chunk[T] // освобожденная страница, сохраняемая для повторного использования
+	layout
+}
+
+// NewDeque создает пустую двустороннюю очередь.
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// Len возвращает актуальное кол-во хранимых элементов.
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+// Get позволяет получить адрес элемента по его порядковому номеру, считая от головы очереди.
+func (d *Deque[T]) Get(i int) *T {
+	if uint(i) >= uint(d.n) {
+		return nil
+	}
+	return d.at(i)
+}
+
+// PushBack добавляет элемент в хвост очереди с индексом равным текущему Len.
+func (d *Deque[T]) PushBack(v T) *T {
+	if (d.head+d.n)>>d.shift == d.hi-d.lo {
+		if d.hi == len(d.buf) {
+			d.regrow()
+		}
+		d.buf[d.hi] = d.take()
+		d.hi++
+	}
+	p := d.at(d.n)
+	*p = v
+	d.n++
+	return p
+}
+
+// PushFront добавляет элемент в голову очереди, после чего он получает индекс 0, а индексы остальных элементов увеличиваются на единицу.
+func (d *Deque[T]) PushFront(v T) *T {
+	if d.head == 0 {
+		if d.lo == 0 {
+			d.regrow()
+		}
+		d.lo--
+		d.buf[d.lo] = d.take()
+		d.head = d.size()
+	}
+	d.head--
+	d.n++
+	p := d.at(0)
+	*p = v
+	return p
+}
+
+// PopBack возвращает последний элемент с удалением его из очереди.
+func (d *Deque[T]) PopBack() T {
+	var zero T
+	if d.n == 0 {
+		return zero
+	}
+	d.n--
+	p := d.at(d.n)
+	v := *p
+	*p = zero
+	if (d.head+d.n)&d.mask == 0 {
+		d.hi--
+		d.put(d.buf[d.hi])
+		d.buf[d.hi] = nil
+	}
+	return v
+}
+
+// PopFront возвращает первый элемент с удалением его из очереди, после чего индексы остальных элементов уменьшаются на единицу.
+func (d *Deque[T]) PopFront() T {
+	var zero T
+	if d.n == 0 {
+		return zero
+	}
+	p := d.at(0)
+	v := *p
+	*p = zero
+	d.head++
+	d.n--
+	if d.head == d.size() {
+		d.put(d.buf[d.lo])
+		d.buf[d.lo] = nil
+		d.lo++
+		d.head = 0
+	}
+	return v
+}
+
+// at возвращает адрес элемента без проверки границ.
+func (d *Deque[T]) at(i int) *T {
+	p := d.head + i
+	return &d.buf[d.lo+p>>d.shift][p&d.mask]
+}
+
+// take возвращает пустую страницу, по возможности повторно используя освобожденную.
+func (d *Deque[T]) take() chunk[T] {
+	if p := d.spare; p != nil {
+		d.spare = nil
+		return p
+	}
+	if d.mask == 0 {
+		// размер страниц вычисляется при выделении первой из них, чтобы нулевое значение Deque было готово к работе
+		d.layout = layoutOf[T]()
+	}
+	return make(chunk[T], d.size())
+}
+
+// put сохраняет освободившуюся страницу для повторного использования. Все элементы страницы к этому моменту обнулены.
+func (d *Deque[T]) put(p chunk[T]) {
+	d.spare = p
+}
+
+// regrow освобождает место в каталоге страниц с обеих сторон от используемой части. Если каталог заполнен меньше,
+// чем наполовину, страницы просто сдвигаются к центру, иначе каталог увеличивается вдвое.
+func (d *Deque[T]) regrow() {
+	used := d.hi - d.lo
+	if used*2 >= len(d.buf) {
+		buf := make([]chunk[T], max(4, len(d.buf)*2))
+		lo := (len(buf) - used) / 2
+		copy(buf[lo:], d.buf[d.lo:d.hi])
+		d.buf, d.lo, d.hi = buf, lo, lo+used
+		return
+	}
+	lo := (len(d.buf) - used) / 2
+	copy(d.buf[lo:], d.buf[d.lo:d.hi])
+	clear(d.buf[:lo])
+	clear(d.buf[lo+used:])
+	d.lo, d.hi = lo, lo+used
+}
@@ -0,0 +1,209 @@
+package collection
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeque(t *testing.T) {
+	t.Parallel()
+	t.Run("push_pop", func(t *testing.T) {
+		t.Parallel()
+
+		var d = NewDeque[string]()
+		d.PushBack("b")
+		d.PushFront("a")
+		d.PushBack("c")
+		require.Equal(t, 3, d.Len())
+		require.Equal(t, "a", *d.Get(0))
+		require.Equal(t, "b", *d.Get(1))
+		require.Equal(t, "c", *d.Get(2))
+		require.Nil(t, d.Get(3))
+		require.Nil(t, d.Get(-1))
+
+		require.Equal(t, "a", d.PopFront())
+		require.Equal(t, "c", d.PopBack())
+		require.Equal(t, "b", d.PopFront())
+		require.Zero(t, d.Len())
+		require.Equal(t, "", d.PopFront())
+		require.Equal(t, "", d.PopBack())
+	})
+	t.Run("queue", func(t *testing.T) {
+		t.Parallel()
+
+		var d Deque[int]
+		const elemCount = 10 * chunkSize
+		for n := 0; n < elemCount; n++ {
+			d.PushBack(n)
+			if n%2 == 1 {
+				require.Equal(t, n/2, d.PopFront())
+			}
+		}
+		require.Equal(t, elemCount/2, d.Len())
+		for n := 0; n < d.Len(); n++ {
+			require.Equal(t, elemCount/2+n, *d.Get(n))
+		}
+		// каталог не растет бесконечно при движении очереди
+		require.LessOrEqual(t, len(d.buf), 16)
+	})
+	t.Run("stack_front", func(t *testing.T) {
+		t.Parallel()
+
+		var d Deque[int]
+		const elemCount = 3*chunkSize + 5
+		for n := 0; n < elemCount; n++ {
+			d.PushFront(n)
+		}
+		for n := 0; n < elemCount; n++ {
+			require.Equal(t, elemCount-1-n, *d.Get(n))
+		}
+		for n := elemCount - 1; n >= 0; n-- {
+			require.Equal(t, n, d.PopFront())
+		}
+		require.Zero(t, d.Len())
+	})
+	t.Run("pointer_stability", func(t *testing.T) {
+		t.Parallel()
+
+		var d Deque[int]
+		var ptrs []*int
+		for n := 0; n < 5*chunkSize; n++ {
+			if n%2 == 0 {
+				ptrs = append(ptrs, d.PushBack(n))
+			} else {
+				ptrs = append([]*int{d.PushFront(n)}, ptrs...)
+			}
+		}
+		for i, p := range ptrs {
+			require.Same(t, p, d.Get(i))
+		}
+	})
+	t.Run("large_elements", func(t *testing.T) {
+		t.Parallel()
+
+		type item [512]byte
+		var d Deque[item]
+		const elemCount = 300
+		for n := 0; n < elemCount; n++ {
+			d.PushFront(item{byte(n)})
+			d.PushBack(item{byte(n)})
+		}
+		require.Equal(t, pageBytes/512, d.size())
+		for n := elemCount - 1; n >= 0; n-- {
+			require.Equal(t, byte(n), d.PopFront()[0])
+			require.Equal(t, byte(n), d.PopBack()[0])
+		}
+		require.Zero(t, d.Len())
+	})
+	t.Run("random", func(t *testing.T) {
+		t.Parallel()
+
+		var d Deque[int]
+		var ref []int
+		rnd := rand.New(rand.NewPCG(9, 10))
+		for n := 0; n < 100_000; n++ {
+			switch rnd.IntN(5) {
+			case 0:
+				d.PushBack(n)
+				ref = append(ref, n)
+			case 1:
+				d.PushFront(n)
+				ref = slices.Insert(ref, 0, n)
+			case 2:
+				var want int
+				if len(ref) > 0 {
+					want, ref = ref[len(ref)-1], ref[:len(ref)-1]
+				}
+				require.Equal(t, want, d.PopBack())
+			case 3:
+				var want int
+				if len(ref) > 0 {
+					want, ref = ref[0], ref[1:]
+				}
+				require.Equal(t, want, d.PopFront())
+			case 4:
+				if len(ref) > 0 {
+					i := rnd.IntN(len(ref))
+					require.Equal(t, ref[i], *d.Get(i))
+				}
+			}
+			require.Equal(t, len(ref), d.Len())
+		}
+		for i, v := range ref {
+			require.Equal(t, v, *d.Get(i))
+		}
+	})
+}
+
+func BenchmarkDequePushPop(b *testing.B) {
+	type Elem struct {
+		s          string
+		a, b, c, d int64
+		n          int
+	}
+	b.Run("PushBack_All", func(b *testing.B) {
+		b.ReportAllocs()
+		var d = NewDeque[Elem]()
+		for n := 0; n < b.N; n++ {
+			d.PushBack(Elem{n: n, s: "PushBack_All"})
+		}
+	})
+	b.Run("PushFront_All", func(b *testing.B) {
+		b.ReportAllocs()
+		var d = NewDeque[Elem]()
+		for n := 0; n < b.N; n++ {
+			d.PushFront(Elem{n: n, s: "PushFront_All"})
+		}
+	})
+	b.Run("Push_Then_Pop", func(b *testing.B) {
+		b.ReportAllocs()
+		var d = NewDeque[Elem]()
+		for n := 0; n < b.N; n++ {
+			d.PushBack(Elem{n: n, s: "Push_Then_Pop"})
+			_ = d.PopBack()
+		}
+	})
+	b.Run("PushFront_Then_PopFront", func(b *testing.B) {
+		b.ReportAllocs()
+		var d = NewDeque[Elem]()
+		for n := 0; n < b.N; n++ {
+			d.PushFront(Elem{n: n, s: "PushFront_Then_PopFront"})
+			_ = d.PopFront()
+		}
+	})
+	b.Run("Push_Push_Then_PopFront", func(b *testing.B) {
+		b.ReportAllocs()
+		var d = NewDeque[Elem]()
+		for n := 0; n < b.N; n++ {
+			d.PushBack(Elem{a: 0, n: n, s: "Push_Push_Then_PopFront"})
+			d.PushBack(Elem{a: 1, n: n, s: "Push_Push_Then_PopFront"})
+			_ = d.PopFront()
+		}
+	})
+	b.Run("Queue_1K", func(b *testing.B) {
+		b.ReportAllocs()
+		var d = NewDeque[Elem]()
+		for n := 0; n < 1_000; n++ {
+			d.PushBack(Elem{n: n, s: "Queue_1K"})
+		}
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			d.PushBack(Elem{n: n, s: "Queue_1K"})
+			_ = d.PopFront()
+		}
+	})
+	b.Run("PopFront_All", func(b *testing.B) {
+		b.ReportAllocs()
+		var d = NewDeque[Elem]()
+		for n := 0; n < b.N; n++ {
+			d.PushBack(Elem{n: n, s: "PopFront_All"})
+		}
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			_ = d.PopFront()
+		}
+	})
+}
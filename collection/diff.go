@@ -0,0 +1,85 @@
+package collection
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPatchMismatch возвращается из Apply, если патч не может быть применен к коллекции, например, изменяет несуществующий элемент.
+var ErrPatchMismatch = errors.New("collection: patch does not match collection")
+
+// OpKind вид операции патча.
+type OpKind uint8
+
+const (
+	// OpSet заменяет элемент с индексом Index на Value.
+	OpSet OpKind = iota + 1
+	// OpDelete удаляет все элементы, начиная с индекса Index.
+	OpDelete
+	// OpPush добавляет Value в конец коллекции.
+	OpPush
+)
+
+// Op одна операция патча. Поля экспортированы, чтобы патч можно было передать по сети через encoding/json или encoding/gob.
+type Op[T any] struct {
+	Kind  OpKind
+	Index int
+	Value T
+}
+
+// Diff возвращает патч, который превращает коллекцию from в коллекцию to. Элементы сравниваются попарно по индексам:
+// для каждого отличающегося элемента создается OpSet, лишние элементы удаляются одной операцией OpDelete,
+// недостающие добавляются через OpPush. Поскольку Delete переносит на место удаленного элемента последний,
+// удаление одного элемента из from дает патч всего из двух операций.
+func Diff[T comparable](from, to *Collection[T]) []Op[T] {
+	return DiffFunc(from, to, func(a, b T) bool {
+		return a == b
+	})
+}
+
+// DiffFunc работает как Diff, но сравнивает элементы функцией eq.
+func DiffFunc[T any](from, to *Collection[T], eq func(a, b T) bool) []Op[T] {
+	var ops []Op[T]
+	common := min(from.n, to.n)
+	for i := 0; i < common; i++ {
+		if v := *to.at(i); !eq(*from.at(i), v) {
+			ops = append(ops, Op[T]{Kind: OpSet, Index: i, Value: v})
+		}
+	}
+	if from.n > to.n {
+		ops = append(ops, Op[T]{Kind: OpDelete, Index: to.n})
+	}
+	for i := from.n; i < to.n; i++ {
+		ops = append(ops, Op[T]{Kind: OpPush, Index: i, Value: *to.at(i)})
+	}
+	return ops
+}
+
+// Apply применяет к коллекции c патч, полученный из Diff. Перед применением патч проверяется целиком, поэтому если
+// он не подходит к коллекции, возвращается ErrPatchMismatch, а коллекция остается без изменений.
+// OpSet и OpDelete работают как замена значения и Truncate, то есть Handle удаленных элементов становятся недействительными.
+func Apply[T any](c *Collection[T], patch []Op[T]) error {
+	n := c.n
+	for k, op := range patch {
+		switch {
+		case op.Kind == OpSet && op.Index >= 0 && op.Index < n:
+		case op.Kind == OpDelete && op.Index >= 0 && op.Index <= n:
+			n = op.Index
+		case op.Kind == OpPush && op.Index == n:
+			n++
+		default:
+			return fmt.Errorf("%w: operation %d (kind %d, index %d) on %d elements", ErrPatchMismatch, k, op.Kind, op.Index, n)
+		}
+	}
+	for _, op := range patch {
+		switch op.Kind {
+		case OpSet:
+			*c.ref(op.Index) = op.Value
+		case OpDelete:
+			c.Truncate(op.Index)
+		case OpPush:
+			c.Push(op.Value)
+		}
+	}
+	return nil
+}
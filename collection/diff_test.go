@@ -0,0 +1,109 @@
+package collection
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	t.Run("operations", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b = New[string](), New[string]()
+		a.PushMany("foo", "bar", "baz", "qux")
+		b.PushMany("foo", "bar", "baz", "qux")
+		require.Empty(t, Diff(a, b))
+
+		b.Delete(1)
+		require.Equal(t, []Op[string]{
+			{Kind: OpSet, Index: 1, Value: "qux"},
+			{Kind: OpDelete, Index: 3},
+		}, Diff(a, b))
+
+		b.Push("quux")
+		b.Push("corge")
+		require.Equal(t, []Op[string]{
+			{Kind: OpSet, Index: 1, Value: "qux"},
+			{Kind: OpSet, Index: 3, Value: "quux"},
+			{Kind: OpPush, Index: 4, Value: "corge"},
+		}, Diff(a, b))
+
+		require.NoError(t, Apply(a, Diff(a, b)))
+		require.Equal(t, collect(b), collect(a))
+	})
+	t.Run("func", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b = New[string](), New[string]()
+		a.PushMany("Foo", "bar")
+		b.PushMany("foo", "BAZ")
+		require.Equal(t, []Op[string]{
+			{Kind: OpSet, Index: 1, Value: "BAZ"},
+		}, DiffFunc(a, b, strings.EqualFold))
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		c.PushMany(1, 2, 3)
+		for _, patch := range [][]Op[int]{
+			{{Kind: OpSet, Index: 3, Value: 10}},
+			{{Kind: OpSet, Index: 0, Value: 10}, {Kind: OpDelete, Index: 1}, {Kind: OpSet, Index: 1, Value: 10}},
+			{{Kind: OpDelete, Index: 4}},
+			{{Kind: OpPush, Index: 2, Value: 10}},
+			{{Kind: 0}},
+		} {
+			require.ErrorIs(t, Apply(c, patch), ErrPatchMismatch)
+			require.Equal(t, []int{1, 2, 3}, collect(c))
+		}
+	})
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b = New[int](), New[int]()
+		a.PushMany(1, 2, 3)
+		b.PushMany(1, 5)
+		data, err := json.Marshal(Diff(a, b))
+		require.NoError(t, err)
+
+		var patch []Op[int]
+		require.NoError(t, json.Unmarshal(data, &patch))
+		require.NoError(t, Apply(a, patch))
+		require.Equal(t, []int{1, 5}, collect(a))
+	})
+	t.Run("random", func(t *testing.T) {
+		t.Parallel()
+
+		rnd := rand.New(rand.NewPCG(19, 20))
+		var a, b = New[int](), New[int]()
+		for n := 0; n < 3*chunkSize; n++ {
+			v := rnd.IntN(100)
+			a.Push(v)
+			b.Push(v)
+		}
+		for round := 0; round < 20; round++ {
+			for n := rnd.IntN(2 * chunkSize); n > 0; n-- {
+				switch rnd.IntN(3) {
+				case 0:
+					b.Push(rnd.IntN(100))
+				case 1:
+					b.Delete(rnd.IntN(b.Len() + 1))
+				case 2:
+					if p := b.Get(rnd.IntN(b.Len() + 1)); p != nil {
+						*p = rnd.IntN(100)
+					}
+				}
+			}
+			patch := Diff(a, b)
+			require.LessOrEqual(t, len(patch), max(a.Len(), b.Len()))
+			require.NoError(t, Apply(a, patch))
+			require.Equal(t, collect(b), collect(a))
+			require.Empty(t, Diff(a, b))
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package collection
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Collection[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*Collection[int])(nil)
+	_ json.Marshaler             = (*Collection[int])(nil)
+	_ json.Unmarshaler           = (*Collection[int])(nil)
+	_ io.WriterTo                = (*Collection[int])(nil)
+	_ io.ReaderFrom              = (*Collection[int])(nil)
+)
+
+// ErrCorrupted возвращается при чтении данных, которые не являются сериализованной коллекцией.
+var ErrCorrupted = errors.New("collection: corrupted data")
+
+// WriteTo записывает коллекцию в w в бинарном формате: количество элементов и затем сами элементы в порядке индексов,
+// закодированные через encoding/gob. Элементы кодируются пачками, по одной на страницу, прямо из страниц коллекции,
+// поэтому даже для очень больших коллекций не требуется промежуточная копия всех данных в памяти.
+// На тип T распространяются ограничения encoding/gob: структуры должны иметь хотя бы одно экспортируемое поле,
+// а неэкспортируемые поля не сохраняются. Для остальных типов используйте MarshalJSON или реализуйте у T
+// интерфейс gob.GobEncoder.
+func (c *Collection[T]) WriteTo(w io.Writer) (int64, error) {
+	cw := countingWriter{w: w}
+	enc := gob.NewEncoder(&cw)
+	if err := enc.Encode(int64(c.n)); err != nil {
+		return cw.n, err
+	}
+	for i := 0; i < c.n; i += c.size() {
+		page := c.chunks[i>>c.shift]
+		if err := enc.Encode(page[:min(c.n-i, len(page))]); err != nil {
+			return cw.n, fmt.Errorf("encode elements from %d: %w", i, err)
+		}
+	}
+	return cw.n, nil
+}
+
+// ReadFrom заменяет содержимое коллекции данными, записанными ранее методом WriteTo. Пачки элементов декодируются
+// в промежуточный буфер размером со страницу и переносятся в страницы коллекции. Если r не реализует io.ByteReader,
+// он оборачивается в буфер и может быть прочитан с опережением.
+// При ошибке в коллекции остаются элементы из успешно прочитанных пачек.
+func (c *Collection[T]) ReadFrom(r io.Reader) (int64, error) {
+	cr := countingReader{r: r}
+	dec := gob.NewDecoder(&cr)
+	var n int64
+	if err := dec.Decode(&n); err != nil {
+		return cr.n, err
+	}
+	if n < 0 {
+		return cr.n, ErrCorrupted
+	}
+	c.Reset()
+	var batch []T
+	for read := int64(0); read < n; read += int64(len(batch)) {
+		// gob не передает нулевые поля, поэтому буфер очищается от значений предыдущей пачки
+		clear(batch[:cap(batch)])
+		batch = batch[:0]
+		if err := dec.Decode(&batch); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return cr.n, fmt.Errorf("decode elements from %d: %w", read, err)
+		}
+		if len(batch) == 0 || int64(len(batch)) > n-read {
+			return cr.n, ErrCorrupted
+		}
+		c.PushMany(batch...)
+	}
+	return cr.n, nil
+}
+
+// MarshalBinary кодирует коллекцию в том же формате и с теми же ограничениями на тип T, что и WriteTo.
+func (c *Collection[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary заменяет содержимое коллекции данными, полученными из MarshalBinary.
+func (c *Collection[T]) UnmarshalBinary(data []byte) error {
+	_, err := c.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// MarshalJSON кодирует коллекцию как JSON массив элементов в порядке индексов.
+func (c *Collection[T]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	enc := json.NewEncoder(&buf)
+	for i := 0; i < c.n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(c.at(i)); err != nil {
+			return nil, err
+		}
+		// Encoder завершает каждое значение переводом строки
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON заменяет содержимое коллекции элементами JSON массива. Значение null, как и принято, ничего не меняет.
+func (c *Collection[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('[') {
+		return fmt.Errorf("collection: cannot unmarshal %v into Collection", tok)
+	}
+	c.Reset()
+	for dec.More() {
+		var zero T
+		if err := dec.Decode(c.Push(zero)); err != nil {
+			c.Pop()
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
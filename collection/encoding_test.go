@@ -0,0 +1,169 @@
+package collection
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionEncoding(t *testing.T) {
+	t.Parallel()
+	type Elem struct {
+		ID   int
+		Name string
+		Tags []string
+	}
+	var fill = func(count int) *Collection[Elem] {
+		var c = New[Elem]()
+		for n := 0; n < count; n++ {
+			c.Push(Elem{ID: n, Name: "elem", Tags: []string{"foo"}})
+		}
+		c.Get(0).Tags = nil
+		return c
+	}
+	t.Run("binary", func(t *testing.T) {
+		t.Parallel()
+
+		src := fill(2*chunkSize + 3)
+		data, err := src.MarshalBinary()
+		require.NoError(t, err)
+
+		var dst = New[Elem]()
+		dst.Push(Elem{ID: -1})
+		require.NoError(t, dst.UnmarshalBinary(data))
+		require.Equal(t, collect(src), collect(dst))
+	})
+	t.Run("stream", func(t *testing.T) {
+		t.Parallel()
+
+		src := fill(1000)
+		var buf bytes.Buffer
+		written, err := src.WriteTo(&buf)
+		require.NoError(t, err)
+		require.Equal(t, int64(buf.Len()), written)
+
+		var dst Collection[Elem]
+		read, err := dst.ReadFrom(&buf)
+		require.NoError(t, err)
+		require.Equal(t, written, read)
+		require.Equal(t, collect(src), collect(&dst))
+	})
+	t.Run("binary_truncated", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := fill(10).MarshalBinary()
+		require.NoError(t, err)
+
+		var dst = New[Elem]()
+		require.Error(t, dst.UnmarshalBinary(data[:len(data)-5]))
+		require.Less(t, dst.Len(), 10)
+	})
+	t.Run("batches", func(t *testing.T) {
+		t.Parallel()
+
+		src := fill(3*layoutOf[Elem]().size() + 7)
+		data, err := src.MarshalBinary()
+		require.NoError(t, err)
+
+		// элементы кодируются одной пачкой на страницу
+		dec := gob.NewDecoder(bytes.NewReader(data))
+		var n int64
+		require.NoError(t, dec.Decode(&n))
+		require.Equal(t, int64(src.Len()), n)
+		var sizes []int
+		for {
+			var batch []Elem
+			if err = dec.Decode(&batch); err != nil {
+				break
+			}
+			sizes = append(sizes, len(batch))
+		}
+		require.ErrorIs(t, err, io.EOF)
+		size := layoutOf[Elem]().size()
+		require.Equal(t, []int{size, size, size, 7}, sizes)
+	})
+	t.Run("no_exported_fields", func(t *testing.T) {
+		t.Parallel()
+
+		type hidden struct {
+			id int
+		}
+		var c = New[hidden]()
+		c.Push(hidden{id: 1})
+		_, err := c.MarshalBinary()
+		require.ErrorContains(t, err, "no exported fields")
+
+		data, err := New[hidden]().MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, c.UnmarshalBinary(data))
+		require.Zero(t, c.Len())
+	})
+	t.Run("stale_fields", func(t *testing.T) {
+		t.Parallel()
+
+		// нулевые поля не передаются gob и не должны остаться от предыдущей пачки
+		var src = New[Elem]()
+		size := layoutOf[Elem]().size()
+		for n := 0; n < size; n++ {
+			src.Push(Elem{ID: n + 1, Name: "elem"})
+		}
+		for n := 0; n < size; n++ {
+			src.Push(Elem{})
+		}
+		data, err := src.MarshalBinary()
+		require.NoError(t, err)
+
+		var dst Collection[Elem]
+		require.NoError(t, dst.UnmarshalBinary(data))
+		require.Equal(t, collect(src), collect(&dst))
+	})
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		src := fill(3)
+		data, err := json.Marshal(src)
+		require.NoError(t, err)
+		require.JSONEq(t, `[
+			{"ID":0,"Name":"elem","Tags":null},
+			{"ID":1,"Name":"elem","Tags":["foo"]},
+			{"ID":2,"Name":"elem","Tags":["foo"]}
+		]`, string(data))
+
+		var dst = New[Elem]()
+		require.NoError(t, json.Unmarshal(data, dst))
+		require.Equal(t, collect(src), collect(dst))
+	})
+	t.Run("json_nested", func(t *testing.T) {
+		t.Parallel()
+
+		var doc struct {
+			Items *Collection[int] `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"items":[3,2,1]}`), &doc))
+		require.Equal(t, []int{3, 2, 1}, collect(doc.Items))
+
+		data, err := json.Marshal(doc)
+		require.NoError(t, err)
+		require.Equal(t, `{"items":[3,2,1]}`, string(data))
+
+		data, err = json.Marshal(New[int]())
+		require.NoError(t, err)
+		require.Equal(t, `[]`, string(data))
+	})
+	t.Run("json_invalid", func(t *testing.T) {
+		t.Parallel()
+
+		var dst = New[int]()
+		require.Error(t, json.Unmarshal([]byte(`{"a":1}`), dst))
+		require.Error(t, json.Unmarshal([]byte(`[1,"2"]`), dst))
+		require.Equal(t, []int{1}, collect(dst))
+
+		dst.Push(2)
+		require.NoError(t, json.Unmarshal([]byte(`null`), dst))
+		require.Equal(t, []int{1, 2}, collect(dst))
+	})
+}
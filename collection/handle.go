@@ -0,0 +1,207 @@
+package collection
+
+import "slices"
+
+// Handle стабильная ссылка на элемент коллекции. В отличие от индекса, она продолжает указывать на тот же элемент
+// после того, как Delete переместит его на место удаленного, и становится недействительной после удаления самого элемента.
+// Нулевое значение Handle никогда не указывает ни на какой элемент.
+type Handle struct {
+	slot uint32
+	gen  uint32
+}
+
+// IsZero сообщает, что Handle не был выдан коллекцией.
+func (h Handle) IsZero() bool {
+	return h.slot == 0
+}
+
+// handles хранит таблицу соответствия Handle и текущих индексов элементов.
+// Таблица создается лениво при выдаче первого Handle, до этого Push, Delete и Pop не несут никаких накладных расходов.
+type handles struct {
+	slots []slot   // номер слота - 1 => текущий индекс элемента и поколение
+	owner []uint32 // индекс элемента => номер слота (0, если Handle для элемента не выдавался)
+	free  []uint32 // номера освободившихся слотов для повторного использования
+}
+
+type slot struct {
+	index int
+	gen   uint32
+}
+
+func (h *handles) enabled() bool {
+	return h.owner != nil
+}
+
+// enable включает учет Handle для коллекции из n элементов.
+func (h *handles) enable(n int) {
+	if h.enabled() {
+		return
+	}
+	h.owner = make([]uint32, n, max(n, 8))
+}
+
+// acquire возвращает Handle элемента с индексом i, при необходимости выделяя для него слот.
+func (h *handles) acquire(i int) Handle {
+	if s := h.owner[i]; s != 0 {
+		return Handle{slot: s, gen: h.slots[s-1].gen}
+	}
+	var s uint32
+	if n := len(h.free); n > 0 {
+		s = h.free[n-1]
+		h.free = h.free[:n-1]
+	} else {
+		h.slots = append(h.slots, slot{})
+		s = uint32(len(h.slots))
+	}
+	h.slots[s-1].index = i
+	h.owner[i] = s
+	return Handle{slot: s, gen: h.slots[s-1].gen}
+}
+
+// resolve возвращает текущий индекс элемента, на который указывает Handle.
+func (h *handles) resolve(hd Handle) (int, bool) {
+	if hd.slot == 0 || int(hd.slot) > len(h.slots) {
+		return 0, false
+	}
+	s := h.slots[hd.slot-1]
+	if s.gen != hd.gen {
+		return 0, false
+	}
+	return s.index, true
+}
+
+func (h *handles) push() {
+	if h.enabled() {
+		h.owner = append(h.owner, 0)
+	}
+}
+
+func (h *handles) pushN(n int) {
+	if h.enabled() {
+		// не через append(make(...)): без оптимизации компилятора, отключенной под -race, это аллоцирует временный срез
+		l := len(h.owner)
+		h.owner = slices.Grow(h.owner, n)[:l+n]
+		clear(h.owner[l:])
+	}
+}
+
+// delete отражает удаление элемента i с переносом на его место последнего элемента last.
+func (h *handles) delete(i, last int) {
+	if !h.enabled() {
+		return
+	}
+	h.release(h.owner[i])
+	h.owner[i] = h.owner[last]
+	if s := h.owner[i]; s != 0 {
+		h.slots[s-1].index = i
+	}
+	h.owner = h.owner[:last]
+}
+
+// deleteRange отражает удаление элементов [from, to) со сдвигом последующих элементов вниз.
+func (h *handles) deleteRange(from, to int) {
+	if !h.enabled() {
+		return
+	}
+	for _, s := range h.owner[from:to] {
+		h.release(s)
+	}
+	h.owner = append(h.owner[:from], h.owner[to:]...)
+	h.reindex(from)
+}
+
+// insert отражает вставку элемента на позицию i со сдвигом последующих элементов вверх.
+func (h *handles) insert(i int) {
+	if !h.enabled() {
+		return
+	}
+	// Push уже добавил ячейку для нового элемента в конец
+	copy(h.owner[i+1:], h.owner[i:])
+	h.owner[i] = 0
+	h.reindex(i + 1)
+}
+
+// drop освобождает слот удаляемого элемента i, не сдвигая остальные.
+func (h *handles) drop(i int) {
+	if !h.enabled() {
+		return
+	}
+	h.release(h.owner[i])
+	h.owner[i] = 0
+}
+
+// move отражает перенос элемента from на место to, ячейка from после этого считается пустой.
+func (h *handles) move(from, to int) {
+	if !h.enabled() {
+		return
+	}
+	h.owner[to], h.owner[from] = h.owner[from], 0
+	if s := h.owner[to]; s != 0 {
+		h.slots[s-1].index = to
+	}
+}
+
+// swap отражает перестановку элементов i и j.
+func (h *handles) swap(i, j int) {
+	if !h.enabled() {
+		return
+	}
+	h.owner[i], h.owner[j] = h.owner[j], h.owner[i]
+	for _, k := range [2]int{i, j} {
+		if s := h.owner[k]; s != 0 {
+			h.slots[s-1].index = k
+		}
+	}
+}
+
+// reindex обновляет индексы слотов для всех элементов, начиная с from.
+func (h *handles) reindex(from int) {
+	for i, s := range h.owner[from:] {
+		if s != 0 {
+			h.slots[s-1].index = from + i
+		}
+	}
+}
+
+// release делает недействительными все выданные ранее Handle слота s и возвращает слот в пул.
+func (h *handles) release(s uint32) {
+	if s == 0 {
+		return
+	}
+	h.slots[s-1].gen++
+	h.free = append(h.free, s)
+}
+
+// PushHandle работает как Push, но дополнительно возвращает стабильную ссылку на добавленный элемент.
+func (c *Collection[T]) PushHandle(v T) (*T, Handle) {
+	p := c.Push(v)
+	return p, c.Handle(c.Len() - 1)
+}
+
+// Handle возвращает стабильную ссылку на элемент с индексом i. Для одного и того же элемента всегда возвращается одинаковый Handle.
+// Если элемента с таким индексом нет, возвращается нулевой Handle.
+func (c *Collection[T]) Handle(i int) Handle {
+	if i < 0 || i >= c.Len() {
+		return Handle{}
+	}
+	c.handles.enable(c.Len())
+	return c.handles.acquire(i)
+}
+
+// ResolveHandle возвращает текущий индекс элемента, на который указывает Handle.
+// Если элемент уже удален, вторым значением возвращается false.
+func (c *Collection[T]) ResolveHandle(h Handle) (int, bool) {
+	if !c.handles.enabled() {
+		return 0, false
+	}
+	return c.handles.resolve(h)
+}
+
+// GetByHandle позволяет получить адрес элемента по стабильной ссылке. Для устаревшей ссылки возвращается nil.
+func (c *Collection[T]) GetByHandle(h Handle) *T {
+	i, ok := c.ResolveHandle(h)
+	if !ok {
+		return nil
+	}
+	return c.Get(i)
+}
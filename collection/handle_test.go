@@ -0,0 +1,84 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionHandle(t *testing.T) {
+	t.Parallel()
+	t.Run("follow_relocation", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[string]()
+		_, h0 := c.PushHandle("foo")
+		_, h1 := c.PushHandle("bar")
+		_, h2 := c.PushHandle("baz")
+
+		c.Delete(0)
+		require.Nil(t, c.GetByHandle(h0))
+		require.Equal(t, "bar", *c.GetByHandle(h1))
+		require.Equal(t, "baz", *c.GetByHandle(h2))
+
+		i, ok := c.ResolveHandle(h2)
+		require.True(t, ok)
+		require.Equal(t, 0, i)
+		require.Equal(t, h2, c.Handle(0))
+	})
+	t.Run("stale_after_pop", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		_, h := c.PushHandle(1)
+		require.Equal(t, 1, c.Pop())
+		require.Nil(t, c.GetByHandle(h))
+
+		// слот переиспользуется, но старая ссылка остается недействительной
+		_, h2 := c.PushHandle(2)
+		require.NotEqual(t, h, h2)
+		require.Nil(t, c.GetByHandle(h))
+		require.Equal(t, 2, *c.GetByHandle(h2))
+	})
+	t.Run("lazy_handle", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 10; n++ {
+			c.Push(n)
+		}
+		h := c.Handle(3)
+		require.True(t, c.Handle(10).IsZero())
+		require.True(t, Handle{}.IsZero())
+		require.Nil(t, c.GetByHandle(Handle{}))
+
+		c.Push(10)
+		c.Delete(3)
+		require.Nil(t, c.GetByHandle(h))
+		require.Equal(t, 10, *c.Get(3))
+	})
+	t.Run("force", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		const elemCount = 10000
+		var hs = make([]Handle, elemCount)
+		for n := 0; n < elemCount; n++ {
+			_, hs[n] = c.PushHandle(n)
+		}
+		for n := 0; n < elemCount; n += 3 {
+			i, ok := c.ResolveHandle(hs[n])
+			require.True(t, ok)
+			c.Delete(i)
+		}
+		for n := 0; n < elemCount; n++ {
+			v := c.GetByHandle(hs[n])
+			if n%3 == 0 {
+				require.Nil(t, v)
+				continue
+			}
+			require.NotNil(t, v)
+			require.Equal(t, n, *v)
+		}
+	})
+}
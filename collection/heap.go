@@ -0,0 +1,119 @@
+package collection
+
+// PriorityQueue очередь с приоритетом на основе двоичной кучи, элементы которой хранятся в страницах Collection.
+// Push возвращает Handle, который продолжает указывать на элемент при любых перестановках внутри кучи,
+// поэтому по нему можно изменить приоритет элемента (Fix) или удалить его (Remove).
+// После прогрева очередь не выделяет память: страницы и слоты Handle освобожденных элементов используются повторно.
+type PriorityQueue[T any] struct {
+	c   Collection[T]
+	cmp func(a, b T) int
+}
+
+// NewPriorityQueue создает очередь, в голове которой всегда находится минимальный по cmp элемент.
+func NewPriorityQueue[T any](cmp func(a, b T) int) *PriorityQueue[T] {
+	return &PriorityQueue[T]{cmp: cmp}
+}
+
+// Len возвращает количество элементов в очереди.
+func (q *PriorityQueue[T]) Len() int {
+	return q.c.Len()
+}
+
+// Push добавляет элемент в очередь за O(log n) и возвращает стабильную ссылку на него.
+func (q *PriorityQueue[T]) Push(v T) Handle {
+	_, h := q.c.PushHandle(v)
+	q.up(q.c.Len() - 1)
+	return h
+}
+
+// Peek возвращает адрес минимального элемента без удаления его из очереди или nil, если очередь пуста.
+// Если приоритет элемента изменяется по этому адресу, после этого нужно вызвать Fix.
+func (q *PriorityQueue[T]) Peek() *T {
+	return q.c.Get(0)
+}
+
+// Pop удаляет из очереди и возвращает минимальный элемент за O(log n). Для пустой очереди возвращается нулевое значение.
+func (q *PriorityQueue[T]) Pop() T {
+	n := q.c.Len() - 1
+	if n < 0 {
+		var zero T
+		return zero
+	}
+	q.c.swap(0, n)
+	v := q.c.Pop()
+	q.down(0)
+	return v
+}
+
+// Get возвращает адрес элемента по стабильной ссылке или nil, если элемент уже удален из очереди.
+// Если приоритет элемента изменяется по этому адресу, после этого нужно вызвать Fix.
+func (q *PriorityQueue[T]) Get(h Handle) *T {
+	return q.c.GetByHandle(h)
+}
+
+// Fix восстанавливает порядок кучи после изменения приоритета элемента h. Возвращает false, если элемент уже удален.
+func (q *PriorityQueue[T]) Fix(h Handle) bool {
+	i, ok := q.c.ResolveHandle(h)
+	if !ok {
+		return false
+	}
+	if !q.down(i) {
+		q.up(i)
+	}
+	return true
+}
+
+// Remove удаляет элемент h из очереди за O(log n) и возвращает его. Если элемент уже удален, вторым значением возвращается false.
+func (q *PriorityQueue[T]) Remove(h Handle) (T, bool) {
+	i, ok := q.c.ResolveHandle(h)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	n := q.c.Len() - 1
+	if i != n {
+		q.c.swap(i, n)
+	}
+	v := q.c.Pop()
+	if i != n && !q.down(i) {
+		q.up(i)
+	}
+	return v, true
+}
+
+func (q *PriorityQueue[T]) less(i, j int) bool {
+	return q.cmp(*q.c.at(i), *q.c.at(j)) < 0
+}
+
+// up поднимает элемент i к вершине, пока он меньше родителя.
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !q.less(i, p) {
+			break
+		}
+		q.c.swap(i, p)
+		i = p
+	}
+}
+
+// down опускает элемент i вниз, пока он больше наименьшего из потомков. Возвращает true, если элемент был перемещен.
+func (q *PriorityQueue[T]) down(i int) bool {
+	n, start := q.c.Len(), i
+	for {
+		l := 2*i + 1
+		if l >= n {
+			break
+		}
+		m := l
+		if r := l + 1; r < n && q.less(r, l) {
+			m = r
+		}
+		if !q.less(m, i) {
+			break
+		}
+		q.c.swap(i, m)
+		i = m
+	}
+	return i > start
+}
@@ -0,0 +1,159 @@
+package collection
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+	t.Run("order", func(t *testing.T) {
+		t.Parallel()
+
+		var q = NewPriorityQueue(cmp.Compare[int])
+		require.Nil(t, q.Peek())
+		require.Zero(t, q.Pop())
+
+		var ref []int
+		rnd := rand.New(rand.NewPCG(13, 14))
+		for n := 0; n < 3*chunkSize; n++ {
+			v := rnd.IntN(1000)
+			q.Push(v)
+			ref = append(ref, v)
+		}
+		slices.Sort(ref)
+		require.Equal(t, ref[0], *q.Peek())
+		for _, want := range ref {
+			require.Equal(t, want, q.Pop())
+		}
+		require.Zero(t, q.Len())
+	})
+	t.Run("handles", func(t *testing.T) {
+		t.Parallel()
+
+		type Task struct {
+			name     string
+			priority int
+		}
+		var q = NewPriorityQueue(func(a, b Task) int {
+			return cmp.Compare(a.priority, b.priority)
+		})
+		var hs = make(map[string]Handle)
+		for n, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			hs[name] = q.Push(Task{name: name, priority: n * 10})
+		}
+		for name, h := range hs {
+			require.Equal(t, name, q.Get(h).name)
+		}
+
+		q.Get(hs["e"]).priority = -1
+		require.True(t, q.Fix(hs["e"]))
+		q.Get(hs["a"]).priority = 100
+		require.True(t, q.Fix(hs["a"]))
+
+		v, ok := q.Remove(hs["c"])
+		require.True(t, ok)
+		require.Equal(t, "c", v.name)
+		_, ok = q.Remove(hs["c"])
+		require.False(t, ok)
+		require.False(t, q.Fix(hs["c"]))
+		require.Nil(t, q.Get(hs["c"]))
+
+		var got []string
+		for q.Len() > 0 {
+			got = append(got, q.Pop().name)
+		}
+		require.Equal(t, []string{"e", "b", "d", "f", "a"}, got)
+		require.Nil(t, q.Get(hs["e"]))
+	})
+	t.Run("random", func(t *testing.T) {
+		t.Parallel()
+
+		var q = NewPriorityQueue(cmp.Compare[int])
+		var live = make(map[Handle]int)
+		rnd := rand.New(rand.NewPCG(15, 16))
+		for n := 0; n < 20000; n++ {
+			switch rnd.IntN(4) {
+			case 0, 1:
+				v := rnd.IntN(1000)
+				live[q.Push(v)] = v
+			case 2:
+				for h := range live {
+					v := rnd.IntN(1000)
+					*q.Get(h) = v
+					live[h] = v
+					require.True(t, q.Fix(h))
+					break
+				}
+			case 3:
+				for h, want := range live {
+					v, ok := q.Remove(h)
+					require.True(t, ok)
+					require.Equal(t, want, v)
+					delete(live, h)
+					break
+				}
+			}
+		}
+		require.Equal(t, len(live), q.Len())
+		var ref []int
+		for h, v := range live {
+			require.Equal(t, v, *q.Get(h))
+			ref = append(ref, v)
+		}
+		slices.Sort(ref)
+		for _, want := range ref {
+			require.Equal(t, want, q.Pop())
+		}
+	})
+}
+
+func TestPriorityQueueNoAllocations(t *testing.T) {
+	var q = NewPriorityQueue(cmp.Compare[int])
+	var hs = make([]Handle, 10_000)
+	a := testing.AllocsPerRun(10, func() {
+		for n := range hs {
+			hs[n] = q.Push((n * 127) % len(hs))
+		}
+		for n := 0; n < len(hs); n += 2 {
+			q.Remove(hs[n])
+		}
+		for q.Len() > 0 {
+			q.Pop()
+		}
+	})
+	require.Equal(t, float64(0), a)
+}
+
+func BenchmarkPriorityQueue(b *testing.B) {
+	b.Run("Push_Pop_1K", func(b *testing.B) {
+		b.ReportAllocs()
+		var q = NewPriorityQueue(cmp.Compare[int])
+		for n := 0; n < 1_000; n++ {
+			q.Push((n * 127) % 1_000)
+		}
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			q.Push(n % 1_000)
+			_ = q.Pop()
+		}
+	})
+	b.Run("Push_Fix_Pop_1K", func(b *testing.B) {
+		b.ReportAllocs()
+		var q = NewPriorityQueue(cmp.Compare[int])
+		for n := 0; n < 1_000; n++ {
+			q.Push((n * 127) % 1_000)
+		}
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			h := q.Push(n % 1_000)
+			*q.Get(h) = -n
+			q.Fix(h)
+			_ = q.Pop()
+		}
+	})
+}
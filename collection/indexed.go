@@ -0,0 +1,90 @@
+package collection
+
+// IndexedCollection коллекция с дополнительным индексом по ключу, который вычисляется из элемента функцией key.
+// Индекс остается корректным после Delete с переносом последнего элемента и после Pop, поэтому поиск по ключу
+// выполняется за O(1) без перебора. Ключ элемента нельзя менять через адрес, полученный из Get или GetByKey,
+// иначе индекс перестанет ему соответствовать.
+type IndexedCollection[K comparable, T any] struct {
+	c     Collection[T]
+	key   func(T) K
+	index map[K]int
+}
+
+// NewIndexed создает коллекцию, индексированную по ключу, который возвращает функция key.
+func NewIndexed[K comparable, T any](key func(T) K, opts ...Option) *IndexedCollection[K, T] {
+	var ic = IndexedCollection[K, T]{key: key, index: make(map[K]int)}
+	for _, opt := range opts {
+		opt(&ic.c.options)
+	}
+	return &ic
+}
+
+// Len всегда возвращает актуальное кол-во хранимых элементов.
+func (ic *IndexedCollection[K, T]) Len() int {
+	return ic.c.Len()
+}
+
+// Push сохраняет новый элемент в конце коллекции. Если элемент с таким же ключом уже есть, он заменяется новым значением
+// на своем месте, так что ключи в коллекции всегда уникальны.
+func (ic *IndexedCollection[K, T]) Push(v T) *T {
+	k := ic.key(v)
+	if i, ok := ic.index[k]; ok {
+		p := ic.c.Get(i)
+		*p = v
+		return p
+	}
+	ic.index[k] = ic.c.Len()
+	return ic.c.Push(v)
+}
+
+// Get позволяет получить адрес элемента по его порядковому номеру.
+func (ic *IndexedCollection[K, T]) Get(i int) *T {
+	return ic.c.Get(i)
+}
+
+// GetByKey возвращает адрес элемента с ключом k или nil, если такого элемента нет.
+func (ic *IndexedCollection[K, T]) GetByKey(k K) *T {
+	i, ok := ic.index[k]
+	if !ok {
+		return nil
+	}
+	return ic.c.Get(i)
+}
+
+// IndexOf возвращает текущий индекс элемента с ключом k. Если элемента нет, вторым значением возвращается false.
+func (ic *IndexedCollection[K, T]) IndexOf(k K) (int, bool) {
+	i, ok := ic.index[k]
+	return i, ok
+}
+
+// Delete удаляет элемент из коллекции так же, как Collection.Delete, и обновляет индекс перенесенного элемента.
+func (ic *IndexedCollection[K, T]) Delete(i int) {
+	if uint(i) >= uint(ic.c.Len()) {
+		return
+	}
+	delete(ic.index, ic.key(*ic.c.at(i)))
+	if last := ic.c.Len() - 1; i != last {
+		ic.index[ic.key(*ic.c.at(last))] = i
+	}
+	ic.c.Delete(i)
+}
+
+// DeleteByKey удаляет элемент с ключом k. Возвращает false, если такого элемента нет.
+func (ic *IndexedCollection[K, T]) DeleteByKey(k K) bool {
+	i, ok := ic.index[k]
+	if ok {
+		ic.Delete(i)
+	}
+	return ok
+}
+
+// Pop позволяет вернуть последний элемент с удалением его из хранилища и из индекса.
+func (ic *IndexedCollection[K, T]) Pop() T {
+	if ic.c.Len() == 0 {
+		var zero T
+		return zero
+	}
+	v := ic.c.Pop()
+	delete(ic.index, ic.key(v))
+	return v
+}
@@ -0,0 +1,92 @@
+package collection
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexedCollection(t *testing.T) {
+	t.Parallel()
+
+	type User struct {
+		ID   int
+		Name string
+	}
+	byID := func(u User) int { return u.ID }
+
+	t.Run("lookup", func(t *testing.T) {
+		t.Parallel()
+
+		var c = NewIndexed(byID)
+		c.Push(User{ID: 10, Name: "foo"})
+		c.Push(User{ID: 20, Name: "bar"})
+		c.Push(User{ID: 30, Name: "baz"})
+		require.Equal(t, "bar", c.GetByKey(20).Name)
+		require.Nil(t, c.GetByKey(40))
+
+		// повторный ключ заменяет элемент на месте
+		p := c.Push(User{ID: 20, Name: "qux"})
+		require.Equal(t, 3, c.Len())
+		require.Same(t, c.Get(1), p)
+		require.Equal(t, "qux", c.GetByKey(20).Name)
+	})
+	t.Run("delete_relocation", func(t *testing.T) {
+		t.Parallel()
+
+		var moves [][2]int
+		var c = NewIndexed(byID, OnMove(func(from, to int) {
+			moves = append(moves, [2]int{from, to})
+		}))
+		for n := 1; n <= 4; n++ {
+			c.Push(User{ID: n})
+		}
+		require.True(t, c.DeleteByKey(1))
+		require.False(t, c.DeleteByKey(1))
+		require.Equal(t, [][2]int{{3, 0}}, moves)
+		i, ok := c.IndexOf(4)
+		require.True(t, ok)
+		require.Equal(t, 0, i)
+		require.Equal(t, 4, c.GetByKey(4).ID)
+
+		require.Equal(t, User{ID: 3}, c.Pop())
+		require.Nil(t, c.GetByKey(3))
+		c.Delete(1)
+		c.Delete(5)
+		require.Equal(t, 1, c.Len())
+		require.Nil(t, c.GetByKey(2))
+		require.Equal(t, 4, c.GetByKey(4).ID)
+
+		require.Equal(t, User{ID: 4}, c.Pop())
+		require.Equal(t, User{}, c.Pop())
+	})
+	t.Run("random", func(t *testing.T) {
+		t.Parallel()
+
+		var c = NewIndexed(func(v int) int { return v })
+		rnd := rand.New(rand.NewPCG(17, 18))
+		for n := 0; n < 3*chunkSize; n++ {
+			switch rnd.IntN(4) {
+			case 0, 1:
+				c.Push(rnd.IntN(2000))
+			case 2:
+				c.DeleteByKey(rnd.IntN(2000))
+			case 3:
+				c.Delete(rnd.IntN(c.Len() + 1))
+			}
+		}
+		require.Len(t, c.index, c.Len())
+		for n := 0; n < c.Len(); n++ {
+			v := *c.Get(n)
+			i, ok := c.IndexOf(v)
+			require.True(t, ok)
+			require.Equal(t, n, i)
+		}
+		for c.Len() > 0 {
+			v := c.Pop()
+			require.Nil(t, c.GetByKey(v))
+		}
+		require.Empty(t, c.index)
+	})
+}
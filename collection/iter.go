@@ -0,0 +1,62 @@
+package collection
+
+import "iter"
+
+// All возвращает итератор по индексам и адресам элементов в порядке возрастания индексов.
+// Во время обхода допускается удалять текущий элемент через Delete или Pop: в этом случае следующим будет выдан элемент,
+// занявший его индекс, и ни один элемент не будет пропущен. Удаление других элементов во время обхода может привести
+// к пропуску или повторной выдаче элементов. Элементы, добавленные через Push во время обхода, также будут выданы.
+// Выдаваемые адреса предназначены только для чтения: обход не копирует страницы, разделяемые со снимком,
+// поэтому для изменения элементов во время обхода служит AllMut.
+func (c *Collection[T]) All() iter.Seq2[int, *T] {
+	return c.all(c.at)
+}
+
+// AllMut работает как All, но выдает адреса, запись по которым не затрагивает снимки, как и адреса из Get.
+func (c *Collection[T]) AllMut() iter.Seq2[int, *T] {
+	return c.all(c.ref)
+}
+
+// all реализует обход для All и AllMut, получая адрес элемента через addr.
+func (c *Collection[T]) all(addr func(int) *T) iter.Seq2[int, *T] {
+	return func(yield func(int, *T) bool) {
+		for i := 0; i < c.n; {
+			n := c.n
+			if !yield(i, addr(i)) {
+				return
+			}
+			if c.n >= n {
+				i++
+			}
+		}
+	}
+}
+
+// Values возвращает итератор по адресам элементов в порядке возрастания индексов с той же семантикой, что и All.
+// Адреса, как и в All, предназначены только для чтения.
+func (c *Collection[T]) Values() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		for _, v := range c.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward возвращает итератор по индексам и адресам элементов в порядке убывания индексов.
+// Обратный обход естественным образом сочетается с Delete: на место удаленного текущего элемента переезжает уже
+// выданный последний, поэтому обход просто продолжается со следующего индекса. Элементы, добавленные через Push
+// во время обхода, выданы не будут. Адреса, как и в All, предназначены только для чтения.
+func (c *Collection[T]) Backward() iter.Seq2[int, *T] {
+	return func(yield func(int, *T) bool) {
+		for i := c.n - 1; i >= 0; i-- {
+			if i = min(i, c.n-1); i < 0 {
+				return
+			}
+			if !yield(i, c.at(i)) {
+				return
+			}
+		}
+	}
+}
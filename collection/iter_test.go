@@ -0,0 +1,154 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionIter(t *testing.T) {
+	t.Parallel()
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 10000; n++ {
+			c.Push(n)
+		}
+		var want int
+		for i, v := range c.All() {
+			require.Equal(t, want, i)
+			require.Same(t, c.Get(i), v)
+			want++
+		}
+		require.Equal(t, c.Len(), want)
+	})
+	t.Run("values_break", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[string]()
+		c.Push("foo")
+		c.Push("bar")
+		c.Push("baz")
+		var got []string
+		for v := range c.Values() {
+			got = append(got, *v)
+			if *v == "bar" {
+				break
+			}
+		}
+		require.Equal(t, []string{"foo", "bar"}, got)
+	})
+	t.Run("backward", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		c.Push(1)
+		c.Push(2)
+		c.Push(3)
+		var got []int
+		for i, v := range c.Backward() {
+			require.Equal(t, *c.Get(i), *v)
+			got = append(got, *v)
+		}
+		require.Equal(t, []int{3, 2, 1}, got)
+	})
+	t.Run("after_snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 2*chunkSize; n++ {
+			c.Push(n)
+		}
+		s := c.Snapshot()
+		// чтение не копирует страницы, разделяемые со снимком
+		for range c.All() {
+		}
+		for range c.Backward() {
+		}
+		for k := range s.chunks {
+			require.Same(t, &c.chunks[k][0], &s.chunks[k][0])
+		}
+		// запись через AllMut выполняется в копиях страниц
+		for i, v := range c.AllMut() {
+			require.Equal(t, i, *v)
+			*v = -i
+		}
+		for i, v := range s.All() {
+			require.Equal(t, i, *v)
+			require.Equal(t, -i, *c.Get(i))
+		}
+	})
+	t.Run("all_delete_current", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 1000; n++ {
+			c.Push(n)
+		}
+		var seen int
+		for i, v := range c.All() {
+			seen++
+			if *v%2 == 0 {
+				c.Delete(i)
+			}
+		}
+		require.Equal(t, 1000, seen)
+		require.Equal(t, 500, c.Len())
+		for v := range c.Values() {
+			require.Equal(t, 1, *v%2)
+		}
+	})
+	t.Run("all_pop_current", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		c.Push(1)
+		c.Push(2)
+		var seen int
+		for i := range c.All() {
+			seen++
+			if i == c.Len()-1 {
+				c.Pop()
+			}
+		}
+		require.Equal(t, 2, seen)
+		require.Equal(t, 1, c.Len())
+	})
+	t.Run("backward_delete_current", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 1000; n++ {
+			c.Push(n)
+		}
+		var seen int
+		for i, v := range c.Backward() {
+			seen++
+			if *v%3 == 0 {
+				c.Delete(i)
+			}
+		}
+		require.Equal(t, 1000, seen)
+		require.Equal(t, 666, c.Len())
+		for v := range c.Values() {
+			require.NotZero(t, *v%3)
+		}
+	})
+	t.Run("backward_pop_many", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 10; n++ {
+			c.Push(n)
+		}
+		var got []int
+		for _, v := range c.Backward() {
+			got = append(got, *v)
+			c.Pop()
+			c.Pop()
+		}
+		require.Equal(t, []int{9, 7, 5, 3, 1}, got)
+		require.Zero(t, c.Len())
+	})
+}
@@ -0,0 +1,161 @@
+package collection
+
+type (
+	// Journal журналирующая обертка над Collection, которая записывает операции Push, Delete и Pop и позволяет
+	// откатывать их к контрольной точке и повторять откаченные операции. После отката индексы всех элементов в
+	// точности совпадают с состоянием на момент контрольной точки, в том числе после Delete с переносом последнего
+	// элемента. Изменения значений через полученные адреса не журналируются, а Handle удаленных элементов после
+	// отката не восстанавливаются.
+	Journal[T any] struct {
+		c    *Collection[T]
+		ops  []journalOp[T]
+		pos  int   // количество примененных операций, ops[pos:] можно повторить
+		redo []int // позиции, к которым возвращает Redo, в порядке откатов
+	}
+
+	journalOp[T any] struct {
+		kind  journalKind
+		index int
+		value T
+	}
+
+	journalKind uint8
+)
+
+const (
+	journalPush journalKind = iota
+	journalDelete
+	journalPop
+)
+
+// NewJournal создает журнал изменений для коллекции c. Все изменения коллекции должны выполняться через журнал.
+func NewJournal[T any](c *Collection[T]) *Journal[T] {
+	return &Journal[T]{c: c}
+}
+
+// Collection возвращает журналируемую коллекцию для операций чтения.
+func (j *Journal[T]) Collection() *Collection[T] {
+	return j.c
+}
+
+// Len всегда возвращает актуальное кол-во хранимых элементов.
+func (j *Journal[T]) Len() int {
+	return j.c.Len()
+}
+
+// Get позволяет получить адрес элемента по его порядковому номеру.
+func (j *Journal[T]) Get(i int) *T {
+	return j.c.Get(i)
+}
+
+// Push сохраняет новый элемент в коллекции и записывает операцию в журнал.
+func (j *Journal[T]) Push(v T) *T {
+	j.record(journalOp[T]{kind: journalPush, index: j.c.Len(), value: v})
+	return j.c.Push(v)
+}
+
+// Delete удаляет элемент из коллекции, перенося на его место последний, и записывает операцию в журнал.
+func (j *Journal[T]) Delete(i int) {
+	p := j.c.Get(i)
+	if p == nil {
+		return
+	}
+	j.record(journalOp[T]{kind: journalDelete, index: i, value: *p})
+	j.c.Delete(i)
+}
+
+// Pop возвращает последний элемент с удалением его из коллекции и записывает операцию в журнал.
+func (j *Journal[T]) Pop() T {
+	if j.c.Len() == 0 {
+		var zero T
+		return zero
+	}
+	v := j.c.Pop()
+	j.record(journalOp[T]{kind: journalPop, index: j.c.Len(), value: v})
+	return v
+}
+
+// Checkpoint возвращает контрольную точку, соответствующую текущему состоянию коллекции.
+func (j *Journal[T]) Checkpoint() int {
+	return j.pos
+}
+
+// Rollback откатывает все операции, выполненные после контрольной точки to. Откаченные операции можно вернуть
+// вызовом Redo, пока через журнал не выполнено новое изменение. Возвращает false, если контрольная точка недействительна.
+func (j *Journal[T]) Rollback(to int) bool {
+	if to < 0 || to > j.pos {
+		return false
+	}
+	if to == j.pos {
+		return true
+	}
+	j.redo = append(j.redo, j.pos)
+	for ; j.pos > to; j.pos-- {
+		j.undo(&j.ops[j.pos-1])
+	}
+	return true
+}
+
+// Redo повторяет операции, откаченные последним вызовом Rollback. Возвращает false, если повторять нечего.
+func (j *Journal[T]) Redo() bool {
+	if len(j.redo) == 0 {
+		return false
+	}
+	to := j.redo[len(j.redo)-1]
+	j.redo = j.redo[:len(j.redo)-1]
+	for ; j.pos < to; j.pos++ {
+		j.apply(&j.ops[j.pos])
+	}
+	return true
+}
+
+// Forget очищает журнал, не меняя коллекцию. Все выданные ранее контрольные точки становятся недействительными.
+func (j *Journal[T]) Forget() {
+	clear(j.ops)
+	j.ops, j.pos, j.redo = j.ops[:0], 0, j.redo[:0]
+}
+
+// record добавляет операцию в журнал, отбрасывая откаченные операции, которые больше нельзя повторить.
+func (j *Journal[T]) record(op journalOp[T]) {
+	clear(j.ops[j.pos:])
+	j.ops = append(j.ops[:j.pos], op)
+	j.pos++
+	j.redo = j.redo[:0]
+}
+
+// undo отменяет операцию op, запоминая в ней актуальное значение элемента для последующего Redo.
+func (j *Journal[T]) undo(op *journalOp[T]) {
+	c := j.c
+	switch op.kind {
+	case journalPush:
+		op.value = c.Pop()
+	case journalPop:
+		c.Push(op.value)
+	case journalDelete:
+		if op.index == c.Len() {
+			c.Push(op.value)
+			return
+		}
+		// возвращаем перенесенный элемент в конец, а удаленный на его прежнее место
+		p := c.Get(op.index)
+		tail := *p
+		*p = op.value
+		c.Push(tail)
+		c.handles.move(op.index, c.Len()-1)
+		c.moved(op.index, c.Len()-1)
+	}
+}
+
+// apply повторно выполняет операцию op.
+func (j *Journal[T]) apply(op *journalOp[T]) {
+	c := j.c
+	switch op.kind {
+	case journalPush:
+		c.Push(op.value)
+	case journalPop:
+		op.value = c.Pop()
+	case journalDelete:
+		op.value = *c.Get(op.index)
+		c.Delete(op.index)
+	}
+}
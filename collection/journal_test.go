@@ -0,0 +1,140 @@
+package collection
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	t.Parallel()
+	t.Run("rollback_redo", func(t *testing.T) {
+		t.Parallel()
+
+		var j = NewJournal(New[string]())
+		j.Push("a")
+		j.Push("b")
+		j.Push("c")
+		cp := j.Checkpoint()
+
+		j.Delete(0)
+		require.Equal(t, []string{"c", "b"}, collect(j.Collection()))
+		require.Equal(t, "b", j.Pop())
+		j.Push("d")
+		require.Equal(t, []string{"c", "d"}, collect(j.Collection()))
+
+		require.True(t, j.Rollback(cp))
+		require.Equal(t, []string{"a", "b", "c"}, collect(j.Collection()))
+		require.True(t, j.Redo())
+		require.Equal(t, []string{"c", "d"}, collect(j.Collection()))
+		require.False(t, j.Redo())
+
+		require.True(t, j.Rollback(0))
+		require.Zero(t, j.Len())
+		require.False(t, j.Rollback(1))
+		require.False(t, j.Rollback(-1))
+	})
+	t.Run("new_change_drops_redo", func(t *testing.T) {
+		t.Parallel()
+
+		var j = NewJournal(New[int]())
+		j.Push(1)
+		cp := j.Checkpoint()
+		j.Push(2)
+		require.True(t, j.Rollback(cp))
+		j.Push(3)
+		require.False(t, j.Redo())
+		require.Equal(t, []int{1, 3}, collect(j.Collection()))
+	})
+	t.Run("nested_rollbacks", func(t *testing.T) {
+		t.Parallel()
+
+		var j = NewJournal(New[int]())
+		j.Push(1)
+		cp1 := j.Checkpoint()
+		j.Push(2)
+		cp2 := j.Checkpoint()
+		j.Push(3)
+		require.True(t, j.Rollback(cp2))
+		require.True(t, j.Rollback(cp1))
+		require.Equal(t, []int{1}, collect(j.Collection()))
+		require.True(t, j.Redo())
+		require.Equal(t, []int{1, 2}, collect(j.Collection()))
+		require.True(t, j.Redo())
+		require.Equal(t, []int{1, 2, 3}, collect(j.Collection()))
+	})
+	t.Run("forget", func(t *testing.T) {
+		t.Parallel()
+
+		var j = NewJournal(New[int]())
+		j.Push(1)
+		j.Forget()
+		require.Zero(t, j.Checkpoint())
+		require.True(t, j.Rollback(0))
+		require.Equal(t, []int{1}, collect(j.Collection()))
+		j.Delete(5)
+		require.Zero(t, j.Checkpoint(), "out of range Delete is not recorded")
+	})
+	t.Run("handles", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[string]()
+		var j = NewJournal(c)
+		j.Push("a")
+		j.Push("b")
+		j.Push("c")
+		ha, hc := c.Handle(0), c.Handle(2)
+		cp := j.Checkpoint()
+
+		j.Delete(0)
+		require.Equal(t, "c", *c.GetByHandle(hc))
+		require.True(t, j.Rollback(cp))
+		require.Equal(t, []string{"a", "b", "c"}, collect(c))
+		require.Equal(t, "c", *c.GetByHandle(hc))
+		i, ok := c.ResolveHandle(hc)
+		require.True(t, ok)
+		require.Equal(t, 2, i)
+		// Handle удаленного элемента не восстанавливается
+		require.Nil(t, c.GetByHandle(ha))
+
+		require.True(t, j.Redo())
+		require.Equal(t, []string{"c", "b"}, collect(c))
+		require.Equal(t, "c", *c.GetByHandle(hc))
+	})
+	t.Run("random_layout", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		var j = NewJournal(c)
+		rnd := rand.New(rand.NewPCG(11, 12))
+		var (
+			states = [][]int{collect(c)}
+			points = []int{j.Checkpoint()}
+		)
+		for n := 0; n < 2000; n++ {
+			switch rnd.IntN(4) {
+			case 0, 1:
+				j.Push(n)
+			case 2:
+				if j.Len() > 0 {
+					j.Delete(rnd.IntN(j.Len()))
+				}
+			case 3:
+				j.Pop()
+			}
+			if n%100 == 0 {
+				states = append(states, collect(c))
+				points = append(points, j.Checkpoint())
+			}
+		}
+		final := collect(c)
+		for k := len(points) - 1; k >= 0; k-- {
+			require.True(t, j.Rollback(points[k]))
+			require.Equal(t, states[k], collect(c))
+		}
+		for j.Redo() {
+		}
+		require.Equal(t, final, collect(c))
+	})
+}
@@ -0,0 +1,230 @@
+//go:build linux || darwin || freebsd
+
+package collection
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"syscall"
+	"unsafe"
+)
+
+// mappedMagic отмечает файлы, созданные Mapped.
+var mappedMagic = [8]byte{'L', 'Y', 'C', 'E', 'U', 'M', 'C', '1'}
+
+// ErrPointerType возвращается при попытке хранить в Mapped тип, содержащий указатели.
+var ErrPointerType = errors.New("collection: element type must not contain pointers")
+
+type (
+	// Mapped коллекция, хранилищем которой служит отображенный в память файл. Подходит для типов фиксированного размера
+	// без указателей (числа, массивы и структуры из них) и позволяет хранить данные, превышающие объем оперативной памяти,
+	// между перезапусками процесса. Контракт Push/Get/Delete/Pop/Len такой же, как у Collection, но адреса элементов
+	// остаются действительными только до следующего увеличения файла в Push или Grow.
+	Mapped[T any] struct {
+		f    *os.File
+		mem  []byte
+		hdr  *mappedHeader
+		data []T
+	}
+
+	// mappedHeader лежит в начале файла и отображается в память вместе с данными.
+	mappedHeader struct {
+		magic    [8]byte
+		elemSize uint64
+		n        uint64
+		_        [40]byte
+	}
+)
+
+const mappedHeaderSize = int(unsafe.Sizeof(mappedHeader{}))
+
+// OpenMapped открывает файл коллекции, созданный ранее, или создает новый, если файла нет.
+// Возвращает ошибку, если тип элементов содержит указатели или не совпадает по размеру с сохраненным в файле.
+func OpenMapped[T any](path string) (*Mapped[T], error) {
+	var zero T
+	size := unsafe.Sizeof(zero)
+	if size == 0 {
+		return nil, fmt.Errorf("collection: zero-size element type %T", zero)
+	}
+	if hasPointers(reflect.TypeFor[T]()) {
+		return nil, fmt.Errorf("%w: %T", ErrPointerType, zero)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	m := &Mapped[T]{f: f}
+	if err = m.open(uint64(size)); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Mapped[T]) open(elemSize uint64) error {
+	st, err := m.f.Stat()
+	if err != nil {
+		return err
+	}
+	if st.Size() == 0 {
+		if err = m.remap(chunkSize); err != nil {
+			return err
+		}
+		m.hdr.magic = mappedMagic
+		m.hdr.elemSize = elemSize
+		return nil
+	}
+	if st.Size() < int64(mappedHeaderSize) {
+		return ErrCorrupted
+	}
+	if err = m.mmap(int(st.Size())); err != nil {
+		return err
+	}
+	if m.hdr.magic != mappedMagic || m.hdr.elemSize != elemSize || m.hdr.n > uint64(len(m.data)) {
+		_ = m.munmap()
+		return ErrCorrupted
+	}
+	return nil
+}
+
+// Len всегда возвращает актуальное кол-во хранимых элементов.
+func (m *Mapped[T]) Len() int {
+	return int(m.hdr.n)
+}
+
+// Cap возвращает количество элементов, которое помещается в файл без его увеличения.
+func (m *Mapped[T]) Cap() int {
+	return len(m.data)
+}
+
+// Push сохраняет новый элемент в коллекции с индексом равным текущему Len. Если для элемента нет места, файл
+// увеличивается вдвое; при ошибке ввода-вывода Push паникует, поэтому для явной обработки ошибок место следует
+// резервировать заранее через Grow.
+func (m *Mapped[T]) Push(v T) *T {
+	n := m.Len()
+	if n == len(m.data) {
+		if err := m.Grow(max(n, 1)); err != nil {
+			panic(err)
+		}
+	}
+	p := &m.data[n]
+	*p = v
+	m.hdr.n++
+	return p
+}
+
+// Get позволяет получить адрес элемента по его порядковому номеру.
+func (m *Mapped[T]) Get(i int) *T {
+	if uint(i) >= uint(m.hdr.n) {
+		return nil
+	}
+	return &m.data[i]
+}
+
+// Delete удаляет элемент из коллекции, перенося на его место последний элемент.
+func (m *Mapped[T]) Delete(i int) {
+	n := m.Len()
+	if uint(i) >= uint(n) {
+		return
+	}
+	var zero T
+	m.data[i] = m.data[n-1]
+	m.data[n-1] = zero
+	m.hdr.n--
+}
+
+// Pop позволяет вернуть последний элемент с удалением его из хранилища.
+func (m *Mapped[T]) Pop() T {
+	var zero T
+	n := m.Len()
+	if n == 0 {
+		return zero
+	}
+	v := m.data[n-1]
+	m.data[n-1] = zero
+	m.hdr.n--
+	return v
+}
+
+// Grow увеличивает файл так, чтобы следующие n вызовов Push не требовали его увеличения.
+// Все адреса, полученные ранее из Push и Get, после увеличения файла становятся недействительными.
+func (m *Mapped[T]) Grow(n int) error {
+	need := m.Len() + n
+	if need <= len(m.data) {
+		return nil
+	}
+	return m.remap(max(need, 2*len(m.data)))
+}
+
+// Sync сбрасывает изменения на диск и дожидается завершения записи.
+func (m *Mapped[T]) Sync() error {
+	_, _, errno := syscall.Syscall(syscall.SYS_MSYNC, uintptr(unsafe.Pointer(&m.mem[0])), uintptr(len(m.mem)), syscall.MS_SYNC)
+	if errno != 0 {
+		return os.NewSyscallError("msync", errno)
+	}
+	return m.f.Sync()
+}
+
+// Close освобождает отображение и закрывает файл. Close не гарантирует, что данные записаны на диск, для этого нужен Sync.
+func (m *Mapped[T]) Close() error {
+	return errors.Join(m.munmap(), m.f.Close())
+}
+
+// remap увеличивает файл до размера, вмещающего capacity элементов, и отображает его заново.
+func (m *Mapped[T]) remap(capacity int) error {
+	var zero T
+	size := mappedHeaderSize + capacity*int(unsafe.Sizeof(zero))
+	if err := m.f.Truncate(int64(size)); err != nil {
+		return err
+	}
+	if m.mem != nil {
+		if err := m.munmap(); err != nil {
+			return err
+		}
+	}
+	return m.mmap(size)
+}
+
+func (m *Mapped[T]) mmap(size int) error {
+	mem, err := syscall.Mmap(int(m.f.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return os.NewSyscallError("mmap", err)
+	}
+	var zero T
+	m.mem = mem
+	m.hdr = (*mappedHeader)(unsafe.Pointer(&mem[0]))
+	m.data = unsafe.Slice((*T)(unsafe.Pointer(&mem[mappedHeaderSize])), (size-mappedHeaderSize)/int(unsafe.Sizeof(zero)))
+	return nil
+}
+
+func (m *Mapped[T]) munmap() error {
+	mem := m.mem
+	m.mem, m.hdr, m.data = nil, nil, nil
+	if err := syscall.Munmap(mem); err != nil {
+		return os.NewSyscallError("munmap", err)
+	}
+	return nil
+}
+
+// hasPointers сообщает, что значения типа t содержат указатели и не могут храниться вне кучи Go.
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return t.Len() > 0 && hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
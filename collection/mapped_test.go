@@ -0,0 +1,112 @@
+//go:build linux || darwin || freebsd
+
+package collection
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iv-menshenin/lyceum/collection/collectiontest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapped(t *testing.T) {
+	t.Parallel()
+	type Elem struct {
+		ID    int64
+		Score float64
+		Code  [4]byte
+	}
+	t.Run("contract", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := OpenMapped[Elem](filepath.Join(t.TempDir(), "data"))
+		require.NoError(t, err)
+		defer c.Close()
+
+		require.Equal(t, int64(1), c.Push(Elem{ID: 1}).ID)
+		c.Push(Elem{ID: 2})
+		c.Push(Elem{ID: 3})
+		require.Equal(t, 3, c.Len())
+		c.Delete(0)
+		require.Equal(t, int64(3), c.Get(0).ID)
+		require.Equal(t, int64(2), c.Pop().ID)
+		require.Equal(t, int64(3), c.Pop().ID)
+		require.Zero(t, c.Len())
+		require.Equal(t, Elem{}, c.Pop())
+		require.Nil(t, c.Get(0))
+	})
+	t.Run("reopen", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "data")
+		c, err := OpenMapped[Elem](path)
+		require.NoError(t, err)
+		const elemCount = 3*chunkSize + 10
+		for n := 0; n < elemCount; n++ {
+			c.Push(Elem{ID: int64(n), Score: float64(n) / 2, Code: [4]byte{'a'}})
+		}
+		c.Delete(0)
+		require.NoError(t, c.Sync())
+		require.NoError(t, c.Close())
+
+		c, err = OpenMapped[Elem](path)
+		require.NoError(t, err)
+		defer c.Close()
+		require.Equal(t, elemCount-1, c.Len())
+		require.Equal(t, int64(elemCount-1), c.Get(0).ID)
+		for n := 1; n < c.Len(); n++ {
+			require.Equal(t, Elem{ID: int64(n), Score: float64(n) / 2, Code: [4]byte{'a'}}, *c.Get(n))
+		}
+	})
+	t.Run("grow", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := OpenMapped[int32](filepath.Join(t.TempDir(), "data"))
+		require.NoError(t, err)
+		defer c.Close()
+		require.NoError(t, c.Grow(100_000))
+		require.GreaterOrEqual(t, c.Cap(), 100_000)
+		capacity := c.Cap()
+		for n := 0; n < 100_000; n++ {
+			c.Push(int32(n))
+		}
+		require.Equal(t, capacity, c.Cap())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		_, err := OpenMapped[string](filepath.Join(dir, "str"))
+		require.ErrorIs(t, err, ErrPointerType)
+		_, err = OpenMapped[struct{ p *int }](filepath.Join(dir, "ptr"))
+		require.ErrorIs(t, err, ErrPointerType)
+		_, err = OpenMapped[any](filepath.Join(dir, "any"))
+		require.ErrorIs(t, err, ErrPointerType)
+		_, err = OpenMapped[struct{}](filepath.Join(dir, "empty"))
+		require.Error(t, err)
+
+		path := filepath.Join(dir, "data")
+		c, err := OpenMapped[int64](path)
+		require.NoError(t, err)
+		require.NoError(t, c.Close())
+		_, err = OpenMapped[int32](path)
+		require.ErrorIs(t, err, ErrCorrupted)
+
+		require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o644))
+		_, err = OpenMapped[int64](path)
+		require.ErrorIs(t, err, ErrCorrupted)
+	})
+}
+
+func FuzzMapped(f *testing.F) {
+	collectiontest.Fuzz(f, func(t testing.TB) collectiontest.Collection[int64] {
+		c, err := OpenMapped[int64](filepath.Join(t.TempDir(), "data"))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, c.Close()) })
+		return c
+	}, func(seq int) int64 {
+		return int64(seq)
+	})
+}
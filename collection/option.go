@@ -0,0 +1,47 @@
+package collection
+
+// Option позволяет настроить коллекцию при ее создании через New.
+type Option func(*options)
+
+type options struct {
+	onMove func(from, to int)
+	onSwap func(i, j int)
+}
+
+// OnMove задает функцию, которая вызывается каждый раз, когда коллекция переносит элемент с индекса from на индекс to,
+// например, когда Delete заполняет освободившийся индекс последним элементом. Функция вызывается уже после переноса,
+// поэтому по индексу to находится перенесенный элемент. Это позволяет синхронизировать индексы, сохраненные во внешних
+// структурах (map, sparseset.SparseSet и т.п.).
+// Перестановка двух элементов (SortFunc, StableSortFunc) сообщается через OnSwap. Если он не задан, перестановка
+// приходит двумя вызовами OnMove(i, j) и OnMove(j, i), которые нужно применять как единый обмен: обработчик,
+// копирующий данные по схеме from → to, при таком вызове потеряет значение.
+func OnMove(fn func(from, to int)) Option {
+	return func(o *options) {
+		o.onMove = fn
+	}
+}
+
+// OnSwap задает функцию, которая вызывается, когда коллекция меняет местами элементы i и j, например, при сортировке.
+// Вместе с OnMove это позволяет зеркалить коллекцию во внешнем срезе: OnMove переносит значение, OnSwap меняет местами.
+func OnSwap(fn func(i, j int)) Option {
+	return func(o *options) {
+		o.onSwap = fn
+	}
+}
+
+// moved сообщает о переносе элемента, если задан обработчик OnMove.
+func (o *options) moved(from, to int) {
+	if o.onMove != nil {
+		o.onMove(from, to)
+	}
+}
+
+// swapped сообщает о перестановке элементов: через OnSwap, если он задан, иначе парой вызовов OnMove.
+func (o *options) swapped(i, j int) {
+	if o.onSwap != nil {
+		o.onSwap(i, j)
+		return
+	}
+	o.moved(i, j)
+	o.moved(j, i)
+}
@@ -0,0 +1,110 @@
+package collection
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionOnMove(t *testing.T) {
+	t.Parallel()
+	t.Run("delete_relocation", func(t *testing.T) {
+		t.Parallel()
+
+		type move struct{ from, to int }
+		var moves []move
+		var c = New[string](OnMove(func(from, to int) {
+			moves = append(moves, move{from: from, to: to})
+		}))
+		c.Push("foo")
+		c.Push("bar")
+		c.Push("baz")
+
+		c.Delete(2)
+		require.Empty(t, moves, "deleting the last element moves nothing")
+		c.Delete(0)
+		require.Equal(t, []move{{from: 1, to: 0}}, moves)
+		require.Equal(t, "bar", *c.Get(0))
+		c.Pop()
+		require.Len(t, moves, 1)
+	})
+	t.Run("external_index", func(t *testing.T) {
+		t.Parallel()
+
+		type Elem struct {
+			id int
+		}
+		var index = make(map[int]int)
+		var c *Collection[Elem]
+		c = New[Elem](OnMove(func(_, to int) {
+			index[c.Get(to).id] = to
+		}))
+
+		const elemCount = 10000
+		for n := 0; n < elemCount; n++ {
+			c.Push(Elem{id: n})
+			index[n] = n
+		}
+		for n := 0; n < elemCount; n += 2 {
+			c.Delete(index[n])
+			delete(index, n)
+		}
+		require.Equal(t, elemCount/2, c.Len())
+		for id, i := range index {
+			require.Equal(t, id, c.Get(i).id)
+		}
+	})
+	t.Run("mirror_through_sort", func(t *testing.T) {
+		t.Parallel()
+
+		// внешний срез повторяет коллекцию, перенося значения по схеме from → to
+		var mirror []int
+		var c = New[int](
+			OnMove(func(from, to int) {
+				mirror[to] = mirror[from]
+			}),
+			OnSwap(func(i, j int) {
+				mirror[i], mirror[j] = mirror[j], mirror[i]
+			}),
+		)
+		rnd := rand.New(rand.NewPCG(21, 22))
+		for n := 0; n < 1000; n++ {
+			v := rnd.IntN(100)
+			c.Push(v)
+			mirror = append(mirror, -v)
+		}
+		check := func() {
+			require.Len(t, mirror, c.Len())
+			for i, v := range mirror {
+				require.Equal(t, -*c.Get(i), v, "index %d", i)
+			}
+		}
+
+		c.SortFunc(cmp.Compare[int])
+		check()
+		c.Delete(10)
+		mirror = mirror[:c.Len()]
+		check()
+		c.StableSortFunc(func(a, b int) int { return cmp.Compare(b, a) })
+		check()
+		c.DeleteStable(0)
+		mirror = mirror[:c.Len()]
+		check()
+		require.True(t, slices.IsSortedFunc(collect(c), func(a, b int) int { return cmp.Compare(b, a) }))
+	})
+	t.Run("swap_without_on_swap", func(t *testing.T) {
+		t.Parallel()
+
+		type move struct{ from, to int }
+		var moves []move
+		var c = New[int](OnMove(func(from, to int) {
+			moves = append(moves, move{from: from, to: to})
+		}))
+		c.PushMany(2, 1)
+		c.SortFunc(cmp.Compare[int])
+		require.ElementsMatch(t, []move{{from: 0, to: 1}, {from: 1, to: 0}}, moves)
+	})
+}
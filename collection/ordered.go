@@ -0,0 +1,85 @@
+package collection
+
+// DeleteStable удаляет элемент с индексом i, сдвигая все последующие элементы на одну позицию вниз.
+// В отличие от Delete порядок оставшихся элементов сохраняется, но операция выполняется за O(Len-i).
+func (c *Collection[T]) DeleteStable(i int) {
+	c.DeleteRange(i, i+1)
+}
+
+// DeleteRange удаляет элементы с индексами [from, to), сдвигая последующие элементы вниз с сохранением порядка.
+// Если диапазон выходит за границы коллекции, ничего не происходит.
+func (c *Collection[T]) DeleteRange(from, to int) {
+	if from < 0 || to > c.n || from >= to {
+		return
+	}
+	n, k := c.n, to-from
+	c.copyWithin(from, to, n-to)
+	c.handles.deleteRange(from, to)
+	c.truncate(n - k)
+	for j := to; j < n; j++ {
+		c.moved(j, j-k)
+	}
+}
+
+// InsertAt вставляет элемент на позицию i, сдвигая последующие элементы вверх с сохранением порядка, и возвращает его адрес.
+// Допустимы индексы от 0 до Len включительно, при i == Len вызов равносилен Push. Для других индексов возвращается nil.
+func (c *Collection[T]) InsertAt(i int, v T) *T {
+	if i < 0 || i > c.n {
+		return nil
+	}
+	n := c.n
+	var zero T
+	c.Push(zero)
+	c.copyWithin(i+1, i, n-i)
+	c.handles.insert(i)
+	p := c.ref(i)
+	*p = v
+	for j := n - 1; j >= i; j-- {
+		c.moved(j, j+1)
+	}
+	return p
+}
+
+// copyWithin переносит cnt элементов с позиции src на позицию dst с учетом перекрытия диапазонов, как это делает copy для слайсов.
+func (c *Collection[T]) copyWithin(dst, src, cnt int) {
+	switch {
+	case dst < src:
+		for cnt > 0 {
+			d, s := c.tail(dst), c.tail(src)
+			k := copy(d[:min(len(d), cnt)], s)
+			dst, src, cnt = dst+k, src+k, cnt-k
+		}
+	case dst > src:
+		for cnt > 0 {
+			d, s := c.head(dst+cnt), c.head(src+cnt)
+			k := min(len(d), len(s), cnt)
+			copy(d[len(d)-k:], s[len(s)-k:])
+			cnt -= k
+		}
+	}
+}
+
+// tail возвращает доступную для записи часть страницы, начиная с элемента i и до конца страницы.
+func (c *Collection[T]) tail(i int) []T {
+	return c.page(i)[i&c.mask:]
+}
+
+// head возвращает доступную для записи часть страницы от ее начала до элемента end-1 включительно.
+func (c *Collection[T]) head(end int) []T {
+	return c.page(end - 1)[:(end-1)&c.mask+1]
+}
+
+// truncate отрезает хвост коллекции до n элементов, обнуляя освободившиеся ячейки, чтобы не удерживать ссылки от сборщика мусора.
+// Страницы при этом сохраняются и будут переиспользованы следующими вызовами Push.
+func (c *Collection[T]) truncate(n int) {
+	for i := n; i < c.n; {
+		k := i >> c.shift
+		end := min(c.n, (k+1)<<c.shift)
+		// страницы, разделяемые со снимком, не копируются ради одного обнуления
+		if c.owns(k) {
+			clear(c.chunks[k][i&c.mask : end-k<<c.shift])
+		}
+		i = end
+	}
+	c.n = n
+}
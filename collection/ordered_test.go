@@ -0,0 +1,124 @@
+package collection
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionOrdered(t *testing.T) {
+	t.Parallel()
+	t.Run("delete_stable", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[string]()
+		c.Push("foo")
+		c.Push("bar")
+		c.Push("baz")
+		c.DeleteStable(0)
+		require.Equal(t, 2, c.Len())
+		require.Equal(t, "bar", *c.Get(0))
+		require.Equal(t, "baz", *c.Get(1))
+		require.Nil(t, c.Get(2))
+
+		c.DeleteStable(5)
+		require.Equal(t, 2, c.Len())
+	})
+	t.Run("insert_at", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		require.Nil(t, c.InsertAt(1, 1))
+		c.InsertAt(0, 3)
+		c.InsertAt(0, 1)
+		v := c.InsertAt(1, 2)
+		require.Equal(t, 2, *v)
+		c.InsertAt(3, 4)
+		require.Equal(t, []int{1, 2, 3, 4}, collect(c))
+	})
+	t.Run("across_chunks", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		var ref []int
+		const elemCount = 3*chunkSize + 17
+		for n := 0; n < elemCount; n++ {
+			c.Push(n)
+			ref = append(ref, n)
+		}
+		c.DeleteRange(10, chunkSize+100)
+		ref = slices.Delete(ref, 10, chunkSize+100)
+		require.Equal(t, ref, collect(c))
+
+		for n := 0; n < chunkSize; n++ {
+			c.InsertAt(5, -n)
+			ref = slices.Insert(ref, 5, -n)
+		}
+		require.Equal(t, ref, collect(c))
+
+		c.DeleteRange(0, c.Len())
+		require.Zero(t, c.Len())
+	})
+	t.Run("random", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		var ref []int
+		rnd := rand.New(rand.NewPCG(1, 2))
+		for n := 0; n < 5000; n++ {
+			switch i := rnd.IntN(len(ref) + 1); rnd.IntN(3) {
+			case 0, 1:
+				c.InsertAt(i, n)
+				ref = slices.Insert(ref, i, n)
+			case 2:
+				if i < len(ref) {
+					j := min(len(ref), i+rnd.IntN(50)+1)
+					c.DeleteRange(i, j)
+					ref = slices.Delete(ref, i, j)
+				}
+			}
+		}
+		require.Equal(t, ref, collect(c))
+	})
+	t.Run("handles_and_moves", func(t *testing.T) {
+		t.Parallel()
+
+		var index = make(map[string]int)
+		var c *Collection[string]
+		c = New[string](OnMove(func(from, to int) {
+			index[*c.Get(to)] = to
+		}))
+		var hs = make(map[string]Handle)
+		for _, s := range []string{"a", "b", "c", "d", "e"} {
+			_, hs[s] = c.PushHandle(s)
+			index[s] = c.Len() - 1
+		}
+		c.DeleteStable(1)
+		delete(index, "b")
+		c.InsertAt(0, "z")
+		index["z"] = 0
+		c.DeleteRange(2, 4)
+		delete(index, "c")
+		delete(index, "d")
+
+		require.Equal(t, []string{"z", "a", "e"}, collect(c))
+		for s, i := range index {
+			require.Equal(t, s, *c.Get(i))
+		}
+		require.Equal(t, "a", *c.GetByHandle(hs["a"]))
+		require.Equal(t, "e", *c.GetByHandle(hs["e"]))
+		require.Nil(t, c.GetByHandle(hs["b"]))
+		require.Nil(t, c.GetByHandle(hs["c"]))
+		require.Nil(t, c.GetByHandle(hs["d"]))
+	})
+}
+
+func collect[T any](c *Collection[T]) []T {
+	var res = make([]T, 0, c.Len())
+	for v := range c.Values() {
+		res = append(res, *v)
+	}
+	return res
+}
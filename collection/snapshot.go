@@ -0,0 +1,103 @@
+package collection
+
+import (
+	"iter"
+	"slices"
+)
+
+// Snapshot неизменяемый снимок коллекции на момент вызова Collection.Snapshot. Снимок создается за O(1) и разделяет
+// страницы с живой коллекцией: страница копируется только тогда, когда коллекция впервые меняет ее после снимка.
+// Снимок можно читать из других горутин одновременно с изменением исходной коллекции.
+type Snapshot[T any] struct {
+	chunks []chunk[T]
+	n      int
+	layout
+}
+
+// cow хранит состояние копирования страниц при записи.
+type cow struct {
+	epoch  uint64   // номер текущего поколения, увеличивается при каждом снимке; 0 означает, что снимков не было
+	epochs []uint64 // поколение, в котором страница была создана или скопирована; страницы прошлых поколений разделяются со снимками
+	shared bool     // срез страниц разделяется со снимком и должен быть скопирован перед изменением
+}
+
+// add учитывает новую страницу, которая принадлежит только коллекции.
+func (w *cow) add() {
+	if w.epoch != 0 {
+		w.epochs = append(w.epochs, w.epoch)
+	}
+}
+
+// Snapshot возвращает неизменяемый снимок текущего состояния коллекции. После этого любая запись в коллекцию через
+// ее методы и через адреса, полученные из Get, выполняется в копии страницы, а снимок продолжает видеть старые данные.
+// Адреса, полученные до вызова Snapshot, могут указывать на страницы снимка и не должны использоваться для записи.
+func (c *Collection[T]) Snapshot() *Snapshot[T] {
+	used := (c.n + c.mask) >> c.shift
+	if c.cow.epoch == 0 {
+		c.cow.epochs = make([]uint64, len(c.chunks), cap(c.chunks))
+	}
+	c.cow.epoch++
+	c.cow.shared = true
+	// страницы за пределами Len в снимок не попадают и остаются собственностью коллекции
+	for k := used; k < len(c.chunks); k++ {
+		c.cow.epochs[k] = c.cow.epoch
+	}
+	return &Snapshot[T]{chunks: c.chunks[:used:used], n: c.n, layout: c.layout}
+}
+
+// owns сообщает, что страница k принадлежит только коллекции и может изменяться без копирования.
+func (c *Collection[T]) owns(k int) bool {
+	return c.cow.epoch == 0 || c.cow.epochs[k] == c.cow.epoch
+}
+
+// own делает страницу k собственностью коллекции, копируя ее, если она разделяется со снимком.
+// Это редкий медленный путь, поэтому он не встраивается в методы записи, которые его вызывают.
+//
+//go:noinline
+func (c *Collection[T]) own(k int) {
+	if c.cow.epochs[k] == c.cow.epoch {
+		return
+	}
+	if c.cow.shared {
+		c.chunks = append([]chunk[T](nil), c.chunks...)
+		c.cow.shared = false
+	}
+	c.chunks[k] = slices.Clone(c.chunks[k])
+	c.cow.epochs[k] = c.cow.epoch
+}
+
+// Len возвращает кол-во элементов в снимке.
+func (s *Snapshot[T]) Len() int {
+	return s.n
+}
+
+// Get возвращает адрес элемента снимка по его порядковому номеру или nil, если такого элемента нет.
+// Изменять элемент по этому адресу нельзя.
+func (s *Snapshot[T]) Get(i int) *T {
+	if uint(i) >= uint(s.n) {
+		return nil
+	}
+	return &s.chunks[i>>s.shift][i&s.mask]
+}
+
+// All возвращает итератор по индексам и адресам элементов снимка в порядке возрастания индексов.
+func (s *Snapshot[T]) All() iter.Seq2[int, *T] {
+	return func(yield func(int, *T) bool) {
+		for i := 0; i < s.n; i++ {
+			if !yield(i, &s.chunks[i>>s.shift][i&s.mask]) {
+				return
+			}
+		}
+	}
+}
+
+// Values возвращает итератор по адресам элементов снимка в порядке возрастания индексов.
+func (s *Snapshot[T]) Values() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		for _, v := range s.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package collection
+
+import (
+	"cmp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionSnapshot(t *testing.T) {
+	t.Parallel()
+	t.Run("isolation", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 3*chunkSize; n++ {
+			c.Push(n)
+		}
+		s := c.Snapshot()
+		want := collect(c)
+
+		c.Delete(0)
+		c.Pop()
+		*c.Get(chunkSize + 1) = -1
+		c.Push(100)
+		c.InsertAt(5, 5)
+		c.SortFunc(func(a, b int) int { return cmp.Compare(b, a) })
+
+		require.Equal(t, len(want), s.Len())
+		var got []int
+		for v := range s.Values() {
+			got = append(got, *v)
+		}
+		require.Equal(t, want, got)
+		require.Nil(t, s.Get(-1))
+		require.Nil(t, s.Get(s.Len()))
+		require.Equal(t, 3*chunkSize-1, *s.Get(3*chunkSize - 1))
+	})
+	t.Run("write_through_get", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		c.Push(1)
+		s := c.Snapshot()
+		*c.Get(0) = -1
+		require.Equal(t, 1, *s.Get(0))
+		require.Equal(t, -1, *c.Get(0))
+	})
+	t.Run("stale_addresses", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 10; n++ {
+			c.Push(n)
+		}
+		p := c.Get(5)
+		s := c.Snapshot()
+		c.Delete(0)
+		// после записи в страницу коллекция работает с ее копией, а старый адрес указывает на страницу снимка
+		require.NotSame(t, p, c.Get(5))
+		require.Same(t, p, s.Get(5))
+		*p = 777
+		require.Equal(t, 777, *s.Get(5))
+		require.Equal(t, 5, *c.Get(5))
+		// адрес, полученный заново, изменяет коллекцию
+		*c.Get(5) = -5
+		require.Equal(t, -5, *c.Get(5))
+		require.Equal(t, 777, *s.Get(5))
+	})
+	t.Run("lazy_copy", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 3*chunkSize; n++ {
+			c.Push(n)
+		}
+		s := c.Snapshot()
+		for k := range s.chunks {
+			require.Same(t, &c.chunks[k][0], &s.chunks[k][0])
+		}
+		// чтение через Load не копирует страницу
+		v, ok := c.Load(0)
+		require.True(t, ok)
+		require.Equal(t, 0, v)
+		_, ok = c.Load(c.Len())
+		require.False(t, ok)
+		require.Same(t, &c.chunks[0][0], &s.chunks[0][0])
+		require.Nil(t, c.Get(-1))
+		require.Nil(t, c.Get(c.Len()))
+		// а запись через адрес из Get выполняется в копии страницы
+		*c.Get(chunkSize) = -1
+		require.Same(t, &c.chunks[0][0], &s.chunks[0][0])
+		require.NotSame(t, &c.chunks[1][0], &s.chunks[1][0])
+		require.Same(t, &c.chunks[2][0], &s.chunks[2][0])
+		require.Equal(t, chunkSize, *s.Get(chunkSize))
+		require.Equal(t, -1, *c.Get(chunkSize))
+	})
+	t.Run("many_snapshots", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		var snaps []*Snapshot[int]
+		for n := 0; n < 10; n++ {
+			c.Push(n)
+			*c.Get(0) = n
+			snaps = append(snaps, c.Snapshot())
+		}
+		c.Reset()
+		for n, s := range snaps {
+			require.Equal(t, n+1, s.Len())
+			require.Equal(t, n, *s.Get(0))
+			for i := 1; i < s.Len(); i++ {
+				require.Equal(t, i, *s.Get(i))
+			}
+		}
+	})
+	t.Run("concurrent_readers", func(t *testing.T) {
+		t.Parallel()
+
+		var c = NewConcurrent[int]()
+		for n := 0; n < 2*chunkSize; n++ {
+			c.Push(n)
+		}
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			s := c.Snapshot()
+			var want = make([]int, c.Len())
+			for i := range want {
+				want[i], _ = c.Load(i)
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i, v := range s.All() {
+					if *v != want[i] {
+						t.Errorf("unexpected value %d at %d", *v, i)
+						return
+					}
+				}
+			}()
+			for n := 0; n < chunkSize; n++ {
+				c.Delete(n)
+				c.Push(n)
+				c.Update(n, func(v *int) { *v = n })
+			}
+		}
+		wg.Wait()
+	})
+}
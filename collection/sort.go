@@ -0,0 +1,60 @@
+package collection
+
+import "sort"
+
+// SortFunc сортирует коллекцию на месте по возрастанию в соответствии с функцией сравнения cmp, которая должна
+// возвращать отрицательное число при a < b, положительное при a > b и ноль при равенстве. Сортировка не стабильна.
+// Элементы переставляются внутри страниц коллекции: Handle продолжают указывать на свои элементы, а для каждой
+// перестановки вызывается обработчик OnSwap (или пара вызовов OnMove, если OnSwap не задан).
+func (c *Collection[T]) SortFunc(cmp func(a, b T) int) {
+	sort.Sort(sorter[T]{c: c, cmp: cmp})
+}
+
+// StableSortFunc работает как SortFunc, но сохраняет исходный порядок равных элементов.
+func (c *Collection[T]) StableSortFunc(cmp func(a, b T) int) {
+	sort.Stable(sorter[T]{c: c, cmp: cmp})
+}
+
+// BinarySearchFunc ищет target в отсортированной по cmp коллекции и возвращает индекс, на котором target находится или
+// должен быть вставлен для сохранения порядка, а также признак того, что элемент найден. Поиск выполняется за O(log n).
+func (c *Collection[T]) BinarySearchFunc(target T, cmp func(a, b T) int) (int, bool) {
+	i := sort.Search(c.n, func(i int) bool {
+		return cmp(*c.at(i), target) >= 0
+	})
+	return i, i < c.n && cmp(*c.at(i), target) == 0
+}
+
+// IndexFunc возвращает индекс первого элемента, для которого f возвращает true, или -1, если такого элемента нет.
+func (c *Collection[T]) IndexFunc(f func(T) bool) int {
+	for i := 0; i < c.n; i++ {
+		if f(*c.at(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// swap меняет местами элементы i и j с учетом Handle и обработчиков OnSwap и OnMove.
+func (c *Collection[T]) swap(i, j int) {
+	a, b := c.ref(i), c.ref(j)
+	*a, *b = *b, *a
+	c.handles.swap(i, j)
+	c.swapped(i, j)
+}
+
+type sorter[T any] struct {
+	c   *Collection[T]
+	cmp func(a, b T) int
+}
+
+func (s sorter[T]) Len() int {
+	return s.c.n
+}
+
+func (s sorter[T]) Less(i, j int) bool {
+	return s.cmp(*s.c.at(i), *s.c.at(j)) < 0
+}
+
+func (s sorter[T]) Swap(i, j int) {
+	s.c.swap(i, j)
+}
@@ -0,0 +1,101 @@
+package collection
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionSort(t *testing.T) {
+	t.Parallel()
+	t.Run("sort_func", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		var ref []int
+		rnd := rand.New(rand.NewPCG(3, 4))
+		for n := 0; n < 3*chunkSize+100; n++ {
+			v := rnd.IntN(1000)
+			c.Push(v)
+			ref = append(ref, v)
+		}
+		c.SortFunc(cmp.Compare[int])
+		slices.Sort(ref)
+		require.Equal(t, ref, collect(c))
+	})
+	t.Run("stable_sort_func", func(t *testing.T) {
+		t.Parallel()
+
+		type Elem struct {
+			key, seq int
+		}
+		var c = New[Elem]()
+		rnd := rand.New(rand.NewPCG(5, 6))
+		for n := 0; n < 2*chunkSize+7; n++ {
+			c.Push(Elem{key: rnd.IntN(10), seq: n})
+		}
+		c.StableSortFunc(func(a, b Elem) int {
+			return cmp.Compare(a.key, b.key)
+		})
+		for i := 1; i < c.Len(); i++ {
+			prev, cur := c.Get(i-1), c.Get(i)
+			require.LessOrEqual(t, prev.key, cur.key)
+			if prev.key == cur.key {
+				require.Less(t, prev.seq, cur.seq)
+			}
+		}
+	})
+	t.Run("binary_search", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 2*chunkSize; n++ {
+			c.Push(n * 2)
+		}
+		i, ok := c.BinarySearchFunc(100, cmp.Compare[int])
+		require.True(t, ok)
+		require.Equal(t, 50, i)
+		i, ok = c.BinarySearchFunc(101, cmp.Compare[int])
+		require.False(t, ok)
+		require.Equal(t, 51, i)
+		i, ok = c.BinarySearchFunc(-1, cmp.Compare[int])
+		require.False(t, ok)
+		require.Equal(t, 0, i)
+		i, ok = c.BinarySearchFunc(4*chunkSize, cmp.Compare[int])
+		require.False(t, ok)
+		require.Equal(t, c.Len(), i)
+	})
+	t.Run("index_func", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[string]()
+		require.Equal(t, -1, c.IndexFunc(func(string) bool { return true }))
+		c.PushMany("foo", "bar", "baz")
+		require.Equal(t, 1, c.IndexFunc(func(s string) bool { return s[0] == 'b' }))
+		require.Equal(t, -1, c.IndexFunc(func(s string) bool { return s == "qux" }))
+	})
+	t.Run("handles_and_moves", func(t *testing.T) {
+		t.Parallel()
+
+		var index = make(map[int]int)
+		var c *Collection[int]
+		c = New[int](OnMove(func(_, to int) {
+			index[*c.Get(to)] = to
+		}))
+		var hs = make(map[int]Handle)
+		for n := 100; n > 0; n-- {
+			_, hs[n] = c.PushHandle(n)
+			index[n] = c.Len() - 1
+		}
+		c.SortFunc(cmp.Compare[int])
+		for n := 1; n <= 100; n++ {
+			require.Equal(t, n-1, index[n])
+			i, ok := c.ResolveHandle(hs[n])
+			require.True(t, ok)
+			require.Equal(t, n-1, i)
+		}
+	})
+}
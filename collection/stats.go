@@ -0,0 +1,58 @@
+package collection
+
+import (
+	"slices"
+	"unsafe"
+)
+
+// Stats описывает расход памяти коллекцией. Учитываются только страницы с элементами, служебные таблицы в расчет не входят.
+type Stats struct {
+	Len           int     // количество хранимых элементов
+	Cap           int     // количество элементов, которое помещается в выделенные страницы
+	Chunks        int     // количество выделенных страниц
+	BytesReserved uintptr // память, занятая страницами
+	BytesUsed     uintptr // часть этой памяти, занятая хранимыми элементами
+}
+
+// Stats возвращает текущую статистику расхода памяти коллекцией.
+func (c *Collection[T]) Stats() Stats {
+	size := unsafe.Sizeof(*new(T))
+	return Stats{
+		Len:           c.n,
+		Cap:           c.Cap(),
+		Chunks:        len(c.chunks),
+		BytesReserved: uintptr(c.Cap()) * size,
+		BytesUsed:     uintptr(c.n) * size,
+	}
+}
+
+// ShrinkToFit освобождает страницы, которые после массового удаления элементов не содержат ни одного из них,
+// и уменьшает служебные таблицы до текущего размера. Освобожденная память возвращается сборщику мусора.
+// Адреса оставшихся элементов не меняются.
+func (c *Collection[T]) ShrinkToFit() {
+	used := (c.n + c.mask) >> c.shift
+	if used < cap(c.chunks) {
+		// новый срез страниц не разделяется ни с одним снимком
+		c.chunks = shrink(c.chunks[:used])
+		c.cow.shared = false
+	}
+	if used < cap(c.cow.epochs) {
+		c.cow.epochs = shrink(c.cow.epochs[:used])
+	}
+	if c.handles.enabled() && len(c.handles.owner) < cap(c.handles.owner) {
+		// nil в owner означает, что Handle не используются, поэтому пустая таблица остается непустым срезом
+		c.handles.owner = append(make([]uint32, 0, len(c.handles.owner)), c.handles.owner...)
+	}
+	c.handles.free = shrink(c.handles.free)
+}
+
+// shrink копирует срез в новый массив без запаса емкости, чтобы старый массив мог быть освобожден.
+func shrink[S ~[]E, E any](s S) S {
+	if len(s) == cap(s) {
+		return s
+	}
+	if len(s) == 0 {
+		return nil
+	}
+	return slices.Clone(s)
+}
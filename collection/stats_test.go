@@ -0,0 +1,144 @@
+package collection
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionStats(t *testing.T) {
+	t.Parallel()
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int64]()
+		require.Equal(t, Stats{}, c.Stats())
+		c.ShrinkToFit()
+		require.Equal(t, Stats{}, c.Stats())
+	})
+	t.Run("counters", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int64]()
+		for n := 0; n < chunkSize+10; n++ {
+			c.Push(int64(n))
+		}
+		require.Equal(t, Stats{
+			Len:           chunkSize + 10,
+			Cap:           2 * chunkSize,
+			Chunks:        2,
+			BytesReserved: 2 * chunkSize * 8,
+			BytesUsed:     (chunkSize + 10) * 8,
+		}, c.Stats())
+	})
+	t.Run("large_elements", func(t *testing.T) {
+		t.Parallel()
+
+		// страница ограничена по размеру в байтах, а не количеством элементов
+		var c = New[[1024]byte]()
+		c.Push([1024]byte{1})
+		require.Equal(t, Stats{
+			Len:           1,
+			Cap:           pageBytes / 1024,
+			Chunks:        1,
+			BytesReserved: pageBytes,
+			BytesUsed:     1024,
+		}, c.Stats())
+		for n := 0; n < 100; n++ {
+			c.Push([1024]byte{byte(n)})
+		}
+		require.Equal(t, byte(1), c.Get(0)[0])
+		require.Equal(t, byte(99), c.Get(100)[0])
+		require.LessOrEqual(t, c.Stats().BytesReserved, uintptr(4*pageBytes))
+	})
+	t.Run("small_collection", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		c.Push(1)
+		require.LessOrEqual(t, c.Stats().BytesReserved, uintptr(pageBytes))
+	})
+	t.Run("shrink", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 5*chunkSize; n++ {
+			c.Push(n)
+		}
+		h := c.Handle(10)
+		p := c.Get(10)
+		c.Truncate(chunkSize + 1)
+		require.Equal(t, 5, c.Stats().Chunks)
+
+		c.ShrinkToFit()
+		s := c.Stats()
+		require.Equal(t, 2, s.Chunks)
+		require.Equal(t, chunkSize+1, s.Len)
+		require.Equal(t, 2*chunkSize, s.Cap)
+		require.Same(t, p, c.GetByHandle(h))
+		for n := 0; n < c.Len(); n++ {
+			require.Equal(t, n, *c.Get(n))
+		}
+
+		// коллекция продолжает работать после сжатия
+		for n := c.Len(); n < 3*chunkSize; n++ {
+			c.Push(n)
+		}
+		c.Delete(0)
+		require.Equal(t, 3*chunkSize-1, *c.Get(0))
+		require.Equal(t, 3*chunkSize-1, *c.GetByHandle(c.Handle(0)))
+
+		c.Reset()
+		c.ShrinkToFit()
+		require.Equal(t, Stats{}, c.Stats())
+		c.Push(1)
+		require.Equal(t, 1, *c.Get(0))
+	})
+	t.Run("snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 3*chunkSize; n++ {
+			c.Push(n)
+		}
+		s := c.Snapshot()
+		c.Truncate(10)
+		c.ShrinkToFit()
+		*c.Get(0) = -1
+		c.Push(-2)
+		require.Equal(t, 1, c.Stats().Chunks)
+		require.Equal(t, 3*chunkSize, s.Len())
+		require.Equal(t, 0, *s.Get(0))
+		require.Equal(t, 10, *s.Get(10))
+		require.Equal(t, -2, *c.Get(10))
+	})
+}
+
+// TestCollectionShrinkToFitReleasesMemory не выполняется параллельно, чтобы на показания кучи не влияли другие тесты.
+func TestCollectionShrinkToFitReleasesMemory(t *testing.T) {
+	const pages = 64
+	var c = New[int64]()
+	for n := 0; n < pages*chunkSize; n++ {
+		c.Push(int64(n))
+	}
+	for c.Len() > 10 {
+		c.Pop()
+	}
+	before := heapAlloc()
+	c.ShrinkToFit()
+	after := heapAlloc()
+
+	released := c.Stats().BytesReserved * (pages - 1)
+	require.Equal(t, 1, c.Stats().Chunks)
+	require.GreaterOrEqual(t, before, after+uint64(released)*9/10, "released %d bytes of %d", before-after, released)
+	require.Equal(t, int64(9), *c.Get(9))
+	runtime.KeepAlive(c)
+}
+
+func heapAlloc() uint64 {
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
@@ -0,0 +1,65 @@
+package collection
+
+// DeleteFunc за один проход удаляет из коллекции все элементы, для которых del возвращает true, и возвращает их количество.
+// Оставшиеся элементы сохраняют взаимный порядок и сдвигаются вниз, занимая освободившиеся индексы: для каждого
+// перенесенного элемента вызывается обработчик OnMove, а его Handle продолжает действовать. Handle удаленных
+// элементов становятся недействительными.
+func (c *Collection[T]) DeleteFunc(del func(T) bool) int {
+	return c.compact(func(_, v *T) bool {
+		return !del(*v)
+	})
+}
+
+// CompactFunc заменяет каждую группу подряд идущих элементов, равных по eq, ее первым элементом и возвращает количество
+// удаленных элементов. Семантика перемещения такая же, как у DeleteFunc.
+func (c *Collection[T]) CompactFunc(eq func(a, b T) bool) int {
+	return c.compact(func(prev, v *T) bool {
+		return prev == nil || !eq(*prev, *v)
+	})
+}
+
+// compact оставляет в коллекции только элементы, для которых keep возвращает true, сохраняя их порядок.
+// В keep передается последний сохраненный элемент (или nil) и проверяемый элемент.
+func (c *Collection[T]) compact(keep func(prev, v *T) bool) int {
+	var prev *T
+	w := 0
+	for r := 0; r < c.n; r++ {
+		p := c.at(r)
+		if !keep(prev, p) {
+			c.handles.drop(r)
+			continue
+		}
+		if w != r {
+			*c.ref(w) = *p
+			c.handles.move(r, w)
+			c.moved(r, w)
+		}
+		prev = c.at(w)
+		w++
+	}
+	deleted := c.n - w
+	c.handles.deleteRange(w, c.n)
+	c.truncate(w)
+	return deleted
+}
+
+// Map создает новую коллекцию из результатов применения f к каждому элементу c в порядке индексов.
+func Map[T, U any](c *Collection[T], f func(T) U) *Collection[U] {
+	var res = New[U]()
+	res.Grow(c.n)
+	for i := 0; i < c.n; i++ {
+		res.Push(f(*c.at(i)))
+	}
+	return res
+}
+
+// Filter создает новую коллекцию из элементов c, для которых keep возвращает true, в порядке их индексов.
+func Filter[T any](c *Collection[T], keep func(T) bool) *Collection[T] {
+	var res = New[T]()
+	for i := 0; i < c.n; i++ {
+		if v := c.at(i); keep(*v) {
+			res.Push(*v)
+		}
+	}
+	return res
+}
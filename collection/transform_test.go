@@ -0,0 +1,83 @@
+package collection
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionTransform(t *testing.T) {
+	t.Parallel()
+	t.Run("delete_func", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		var ref []int
+		rnd := rand.New(rand.NewPCG(7, 8))
+		for n := 0; n < 3*chunkSize; n++ {
+			v := rnd.IntN(100)
+			c.Push(v)
+			ref = append(ref, v)
+		}
+		isOdd := func(v int) bool { return v%2 == 1 }
+		before := len(ref)
+		ref = slices.DeleteFunc(ref, isOdd)
+		require.Equal(t, before-len(ref), c.DeleteFunc(isOdd))
+		require.Equal(t, ref, collect(c))
+		require.Zero(t, c.DeleteFunc(isOdd))
+
+		require.Equal(t, len(ref), c.DeleteFunc(func(int) bool { return true }))
+		require.Zero(t, c.Len())
+	})
+	t.Run("delete_func_handles_and_moves", func(t *testing.T) {
+		t.Parallel()
+
+		var index = make(map[int]int)
+		var c *Collection[int]
+		c = New[int](OnMove(func(_, to int) {
+			index[*c.Get(to)] = to
+		}))
+		var hs = make([]Handle, 100)
+		for n := range hs {
+			_, hs[n] = c.PushHandle(n)
+			index[n] = n
+		}
+		c.DeleteFunc(func(v int) bool { return v%3 == 0 })
+		for n, h := range hs {
+			if n%3 == 0 {
+				require.Nil(t, c.GetByHandle(h))
+				continue
+			}
+			require.Equal(t, n, *c.GetByHandle(h))
+			require.Equal(t, n, *c.Get(index[n]))
+		}
+	})
+	t.Run("compact_func", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[string]()
+		c.PushMany("a", "a", "b", "c", "c", "c", "a")
+		require.Equal(t, 3, c.CompactFunc(func(a, b string) bool { return a == b }))
+		require.Equal(t, []string{"a", "b", "c", "a"}, collect(c))
+	})
+	t.Run("map_filter", func(t *testing.T) {
+		t.Parallel()
+
+		var c = New[int]()
+		for n := 0; n < 2*chunkSize; n++ {
+			c.Push(n)
+		}
+		strs := Map(c, strconv.Itoa)
+		require.Equal(t, c.Len(), strs.Len())
+		require.Equal(t, "1234", *strs.Get(1234))
+
+		even := Filter(c, func(v int) bool { return v%2 == 0 })
+		require.Equal(t, chunkSize, even.Len())
+		require.Equal(t, 2468, *even.Get(1234))
+		// исходная коллекция не изменилась
+		require.Equal(t, 2*chunkSize, c.Len())
+	})
+}
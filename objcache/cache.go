@@ -1,5 +1,7 @@
 package objcache
 
+import "unsafe"
+
 // ТРЕБУЕТСЯ: написать реализацию пула кешируемых элементов - по сути КЭШ. Кол-во требуемых элементов в кэше неизвестно.
 // Работа кэша происходит по следующему алгоритму: по мере необходимости из кеша запрашиваются элементы методом Get,
 // каждый из полученных элементов имеет собственный адрес в памяти. После того как работа с объектами выполнена,
@@ -7,23 +9,111 @@ package objcache
 
 // Оценивается скорость работы и количество аллокаций. При повторном запросе элементов, аллокаций в памяти быть не должно.
 
-// Cache примерный принцип реализации пула. Не проходит всех тестов.
-type Cache[V any] struct {
-	d [1000]V
-	i int
+// Элементы выдаются из слабов - блоков памяти, размер которых удваивается от minSlab до maxSlab элементов.
+// Слабы никогда не перемещаются и не освобождаются при Clear, поэтому после первого цикла заполнения
+// любое количество вызовов Get не выделяет память.
+const (
+	minSlab = 64
+	maxSlab = 1 << 16
+)
+
+type (
+	// Cache пул элементов, растущий цепочкой слабов. Нулевое значение готово к работе.
+	Cache[V any] struct {
+		slabs [][]slot[V]
+		cur   []slot[V] // слаб, из которого сейчас выдаются элементы
+		used  int       // количество слабов, из которых выдавались элементы после последнего Clear
+		i     int       // количество выданных элементов из cur
+		free  *slot[V]  // последний возвращенный через Release элемент, начало списка свободных
+		options[V]
+	}
+
+	// slot ячейка слаба. Элемент расположен в начале ячейки, поэтому адрес элемента совпадает с адресом ячейки,
+	// а освобожденные ячейки связываются в список через next без дополнительной памяти.
+	slot[V any] struct {
+		v    V
+		dbg  slotDebug
+		next *slot[V]
+	}
+)
+
+// New создает кэш с дополнительными настройками. Нулевое значение Cache также готово к работе и не очищает элементы.
+func New[V any](opts ...Option[V]) *Cache[V] {
+	var c Cache[V]
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	return &c
 }
 
 // Get выдает адрес к переиспользуемому участку памяти, этот адрес будет зарезервирован до следующего вызова Clear и следующий вызов Get выдаст другой участок памяти.
+// В первую очередь выдаются элементы, возвращенные через Release, начиная с последнего из них.
 func (c *Cache[V]) Get() *V {
-	c.i++
-	if c.i < 1001 {
-		return &c.d[c.i-1]
+	s := c.free
+	if s != nil {
+		c.free = s.next
+	} else {
+		if c.i == len(c.cur) {
+			c.next()
+		}
+		s = &c.cur[c.i]
+		c.i++
+	}
+	s.dbg.acquire()
+	if c.policy != resetNone {
+		c.acquired(&s.v)
+	}
+	return &s.v
+}
+
+// Release досрочно возвращает в кэш один элемент, полученный из Get этого кэша, и он будет выдан следующим вызовом Get.
+// После этого элемент нельзя использовать. Повторное освобождение элемента до того, как Get выдаст его снова,
+// приводит к порче кэша; сборка с тегом objcache_debug обнаруживает такую ошибку и завершается паникой.
+func (c *Cache[V]) Release(p *V) {
+	s := (*slot[V])(unsafe.Pointer(p))
+	s.dbg.release()
+	if c.policy == resetOnClear {
+		var zero V
+		s.v = zero
 	}
-	var v V
-	return &v
+	s.next = c.free
+	c.free = s
 }
 
 // Clear снимает резервирование и помечает все адреса памяти, как свободные. После вызова этого метода Get будет выдавать адреса с самого первого.
+// Выполняется за O(1): слабы сохраняются и используются повторно в том же порядке, а список освобожденных элементов сбрасывается.
+// Только с настройкой ZeroOnClear время Clear пропорционально количеству выданных элементов.
 func (c *Cache[V]) Clear() {
+	if c.policy == resetOnClear {
+		c.zero(0, 0)
+	}
+	c.free = nil
+	c.cur = nil
+	c.used = 0
+	c.i = 0
+}
+
+// next переключает выдачу на следующий слаб, выделяя его, если цепочка слабов еще не достигла такой длины.
+func (c *Cache[V]) next() {
+	if c.used == len(c.slabs) {
+		c.slabs = append(c.slabs, make([]slot[V], min(minSlab<<c.used, maxSlab)))
+	}
+	c.cur = c.slabs[c.used]
+	c.used++
 	c.i = 0
 }
+
+// zero обнуляет все ячейки, выданные после позиции used, i и до текущей позиции.
+func (c *Cache[V]) zero(used, i int) {
+	k := used - 1
+	if k < 0 {
+		k, i = 0, 0
+	}
+	for ; k < c.used; k, i = k+1, 0 {
+		end := len(c.slabs[k])
+		if k == c.used-1 {
+			end = c.i
+		}
+		clear(c.slabs[k][i:end])
+	}
+}
@@ -44,6 +44,24 @@ func testNoAllocations(t *testing.T, count int) {
 	require.Equal(t, float64(0), a)
 }
 
+func TestCacheReleaseNoAllocations(t *testing.T) {
+	var c Cache[int]
+	var v = make([]*int, 10_000)
+	a := testing.AllocsPerRun(10, func() {
+		c.Clear()
+		for n := range v {
+			v[n] = c.Get()
+		}
+		for n := 0; n < len(v); n += 2 {
+			c.Release(v[n])
+		}
+		for n := 0; n < len(v); n++ {
+			v[n] = c.Get()
+		}
+	})
+	require.Equal(t, float64(0), a)
+}
+
 func TestCache(t *testing.T) {
 	t.Run("sizeRestrictions", func(t *testing.T) {
 		require.Less(t, uint32(unsafe.Sizeof(Cache[int]{})), uint32(1024))
@@ -74,6 +92,45 @@ func TestCache(t *testing.T) {
 			m[*vs] = struct{}{}
 		}
 	})
+	t.Run("slabs_retained", func(t *testing.T) {
+		const count = 3*maxSlab + 10
+		var c Cache[int]
+		var v = make([]*int, count)
+		for n := range v {
+			v[n] = c.Get()
+			*v[n] = n
+		}
+		for n := range v {
+			require.Equal(t, n, *v[n], "memory conflict on %d", n)
+		}
+		slabs := len(c.slabs)
+		for round := 0; round < 3; round++ {
+			c.Clear()
+			for n := range v {
+				require.Same(t, v[n], c.Get())
+			}
+		}
+		require.Equal(t, slabs, len(c.slabs))
+	})
+	t.Run("release", func(t *testing.T) {
+		var c Cache[int]
+		v1, v2, v3 := c.Get(), c.Get(), c.Get()
+		c.Release(v1)
+		c.Release(v3)
+		// сначала выдается последний освобожденный элемент
+		require.Same(t, v3, c.Get())
+		require.Same(t, v1, c.Get())
+		v4 := c.Get()
+		require.NotSame(t, v1, v4)
+		require.NotSame(t, v2, v4)
+		require.NotSame(t, v3, v4)
+
+		// Clear сбрасывает список освобожденных элементов
+		c.Release(v2)
+		c.Clear()
+		require.Same(t, v1, c.Get())
+		require.Same(t, v2, c.Get())
+	})
 }
 
 func BenchmarkCache(b *testing.B) {
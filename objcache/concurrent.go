@@ -0,0 +1,151 @@
+package objcache
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// cacheShards количество независимых цепочек слабов ConcurrentCache. Get выбирает шард при каждом вызове,
+// а Local закрепляет шард за горутиной, поэтому пока одновременно работающих горутин не больше cacheShards,
+// они не конкурируют за один счетчик.
+const (
+	cacheShards = 16
+	shardMask   = cacheShards - 1
+)
+
+type (
+	// ConcurrentCache потокобезопасный вариант Cache с той же семантикой циклов Get и Clear.
+	// Элементы выдаются из слабов одного из шардов атомарным увеличением счетчика, блокировка захватывается только
+	// при переходе к следующему слабу. Get выбирает шард случайно при каждом вызове, а горутина, запрашивающая много
+	// элементов, может получить через Local собственный шард и избежать конкуренции с остальными.
+	// Clear можно вызывать только тогда, когда ни одна горутина не выполняет Get и не использует полученные элементы,
+	// например, после sync.WaitGroup.Wait. Нулевое значение готово к работе.
+	ConcurrentCache[V any] struct {
+		shards [cacheShards]cacheShard[V]
+		local  atomic.Uint32 // количество выданных с последнего Clear Local, определяет следующий шард
+	}
+
+	// Local закрепленный за горутиной шард ConcurrentCache. Его можно хранить и использовать в нескольких циклах
+	// Get и Clear. Один Local можно использовать из нескольких горутин, но тогда они конкурируют за общий счетчик.
+	Local[V any] struct {
+		c  *ConcurrentCache[V]
+		sh *shard[V]
+	}
+
+	// cacheShard выровнен по размеру кэш-линии, чтобы соседние шарды не мешали друг другу.
+	cacheShard[V any] struct {
+		shard[V]
+		_ [64 - unsafe.Sizeof(shard[struct{}]{})%64]byte
+	}
+
+	shard[V any] struct {
+		cur   atomic.Pointer[cacheSlab[V]] // слаб, из которого сейчас выдаются элементы
+		mu    sync.Mutex                   // защищает slabs и used при переходе к следующему слабу и при передаче слабов
+		slabs []*cacheSlab[V]
+		used  int
+	}
+
+	// cacheSlab занимает целую кэш-линию, поэтому счетчики слабов разных шардов не попадают в одну линию.
+	cacheSlab[V any] struct {
+		n     atomic.Int64 // количество выданных элементов, после исчерпания слаба может превышать len(items)
+		items []V
+		_     [64 - 8 - unsafe.Sizeof([]V(nil))]byte
+	}
+)
+
+// Get выдает адрес к переиспользуемому участку памяти, этот адрес будет зарезервирован до следующего вызова Clear.
+// Шард выбирается случайно при каждом вызове, поэтому одновременные вызовы из разных горутин редко конкурируют
+// за один счетчик. Адреса, выданные через Get и через любые Local, никогда не совпадают.
+func (c *ConcurrentCache[V]) Get() *V {
+	return c.get(&c.shards[rand.Uint32()&shardMask].shard)
+}
+
+// Local закрепляет за вызывающей горутиной шард кэша. Шарды раздаются по кругу, начиная с первого после каждого Clear.
+func (c *ConcurrentCache[V]) Local() Local[V] {
+	k := c.local.Add(1) - 1
+	return Local[V]{c: c, sh: &c.shards[k&shardMask].shard}
+}
+
+// Get выдает адрес к переиспользуемому участку памяти из закрепленного шарда, этот адрес будет зарезервирован
+// до следующего вызова Clear. Это быстрый путь ConcurrentCache.Get: шард не выбирается заново при каждом вызове.
+func (l Local[V]) Get() *V {
+	return l.c.get(l.sh)
+}
+
+// get выдает элемент из текущего слаба шарда sh, при необходимости переключая шард на следующий слаб.
+func (c *ConcurrentCache[V]) get(sh *shard[V]) *V {
+	for {
+		s := sh.cur.Load()
+		if s != nil {
+			if i := s.n.Add(1) - 1; i < int64(len(s.items)) {
+				return &s.items[i]
+			}
+		}
+		c.next(sh, s)
+	}
+}
+
+// Clear помечает все выданные элементы как свободные. Слабы сохраняются, а шард, которому не хватило своих слабов,
+// забирает неиспользованные слабы других шардов. Поэтому после первого цикла заполнения Get не выделяет память,
+// если в следующих циклах запрашивается не больше элементов, независимо от того, как они распределены по шардам
+// и в каком порядке горутины получают Local. Исключение составляют остатки слабов, не израсходованные до перехода
+// к следующему: при сильно меняющемся распределении они могут потребовать нескольких дополнительных слабов.
+// Выполняется за O(cacheShards) и не синхронизирован с Get: все пользователи кэша должны завершить работу до вызова Clear.
+// Полученные ранее Local остаются действительными, а раздача шардов новым Local начинается заново.
+func (c *ConcurrentCache[V]) Clear() {
+	c.local.Store(0)
+	for k := range c.shards {
+		sh := &c.shards[k]
+		sh.mu.Lock()
+		sh.cur.Store(nil)
+		sh.used = 0
+		sh.mu.Unlock()
+	}
+}
+
+// next переключает шард sh на следующий слаб, если текущим по-прежнему остается исчерпанный слаб old.
+// Слабы, оставшиеся с прошлых циклов, используются повторно: сначала собственные, затем неиспользованные слабы
+// других шардов. Новый слаб выделяется только если свободных слабов не осталось ни у одного шарда.
+func (c *ConcurrentCache[V]) next(sh *shard[V], old *cacheSlab[V]) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if sh.cur.Load() != old {
+		// другая горутина уже переключила слаб
+		return
+	}
+	if sh.used == len(sh.slabs) {
+		if s := c.steal(sh); s != nil {
+			sh.slabs = append(sh.slabs, s)
+		} else {
+			sh.slabs = append(sh.slabs, &cacheSlab[V]{items: make([]V, min(minSlab<<sh.used, maxSlab))})
+		}
+	}
+	s := sh.slabs[sh.used]
+	s.n.Store(0)
+	sh.used++
+	sh.cur.Store(s)
+}
+
+// steal забирает у другого шарда слаб, не использованный им в текущем цикле. Шарды, занятые в этот момент,
+// пропускаются: ожидание их блокировки под блокировкой sh могло бы привести к взаимной блокировке.
+func (c *ConcurrentCache[V]) steal(sh *shard[V]) *cacheSlab[V] {
+	for k := range c.shards {
+		victim := &c.shards[k].shard
+		if victim == sh || !victim.mu.TryLock() {
+			continue
+		}
+		var s *cacheSlab[V]
+		if last := len(victim.slabs) - 1; last >= victim.used {
+			s = victim.slabs[last]
+			victim.slabs[last] = nil
+			victim.slabs = victim.slabs[:last]
+		}
+		victim.mu.Unlock()
+		if s != nil {
+			return s
+		}
+	}
+	return nil
+}
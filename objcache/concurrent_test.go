@@ -0,0 +1,283 @@
+package objcache
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentCache(t *testing.T) {
+	t.Run("layout", func(t *testing.T) {
+		require.Zero(t, unsafe.Sizeof(cacheShard[int]{})%64)
+		require.Equal(t, uintptr(64), unsafe.Sizeof(cacheSlab[int]{}))
+	})
+	t.Run("no_repetative", func(t *testing.T) {
+		const (
+			workers = 8
+			count   = 50_000
+		)
+		var c ConcurrentCache[int]
+		var got [workers][]*int
+		for round := 0; round < 3; round++ {
+			var wg sync.WaitGroup
+			for w := range got {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					got[w] = got[w][:0]
+					l := c.Local()
+					for n := 0; n < count; n++ {
+						p := l.Get()
+						*p = w*count + n
+						got[w] = append(got[w], p)
+					}
+				}()
+			}
+			wg.Wait()
+
+			var seen = make(map[*int]struct{}, workers*count)
+			for w := range got {
+				for n, p := range got[w] {
+					require.Equal(t, w*count+n, *p, "memory conflict")
+					seen[p] = struct{}{}
+				}
+			}
+			require.Len(t, seen, workers*count)
+			c.Clear()
+		}
+	})
+	t.Run("get", func(t *testing.T) {
+		const (
+			workers = 8
+			count   = 20_000
+		)
+		var c ConcurrentCache[int]
+		var got [workers][]*int
+		var wg sync.WaitGroup
+		for w := range got {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// половина горутин получает элементы через Get, остальные через Local
+				get := c.Get
+				if w%2 == 1 {
+					get = c.Local().Get
+				}
+				for n := 0; n < count; n++ {
+					p := get()
+					*p = w*count + n
+					got[w] = append(got[w], p)
+				}
+			}()
+		}
+		wg.Wait()
+
+		var seen = make(map[*int]struct{}, workers*count)
+		for w := range got {
+			for n, p := range got[w] {
+				require.Equal(t, w*count+n, *p, "memory conflict")
+				seen[p] = struct{}{}
+			}
+		}
+		require.Len(t, seen, workers*count)
+	})
+	t.Run("the_same_address", func(t *testing.T) {
+		var c ConcurrentCache[int]
+		l1, l2 := c.Local(), c.Local()
+		v1, v2 := l1.Get(), l2.Get()
+		require.NotSame(t, v1, v2)
+		c.Clear()
+		// после Clear шарды раздаются в том же порядке
+		require.Same(t, v1, c.Local().Get())
+		require.Same(t, v2, c.Local().Get())
+		// ранее полученный Local остается действительным
+		require.NotSame(t, v1, l1.Get())
+	})
+	t.Run("sticky_shard", func(t *testing.T) {
+		var c ConcurrentCache[int]
+		l := c.Local()
+		for n := 0; n < 10_000; n++ {
+			l.Get()
+		}
+		for k := 1; k < cacheShards; k++ {
+			require.Nil(t, c.shards[k].cur.Load(), "shard %d", k)
+		}
+		require.Same(t, &c.shards[0].shard, l.sh)
+		require.Same(t, &c.shards[1].shard, c.Local().sh)
+	})
+}
+
+func TestConcurrentCacheReusable(t *testing.T) {
+	const (
+		workers = cacheShards + 4
+		count   = 10_000
+	)
+	var c ConcurrentCache[int]
+	cycle := func() {
+		for w := 0; w < workers; w++ {
+			l := c.Local()
+			for n := 0; n < count*(w+1); n++ {
+				*l.Get() = n
+			}
+		}
+		c.Clear()
+	}
+	cycle()
+	a := testing.AllocsPerRun(10, cycle)
+	require.Equal(t, float64(0), a)
+}
+
+func TestConcurrentCacheReusableShuffled(t *testing.T) {
+	const count = 10_000
+	var c ConcurrentCache[int]
+	var cycles int
+	// каждый цикл горутины получают Local в другом порядке, поэтому нагрузка на шарды меняется от цикла к циклу
+	cycle := func() {
+		for w := 0; w < cacheShards; w++ {
+			l := c.Local()
+			for n := 0; n < count*((w+cycles)%cacheShards+1); n++ {
+				*l.Get() = n
+			}
+		}
+		c.Clear()
+		cycles++
+	}
+	cycle()
+	a := testing.AllocsPerRun(2*cacheShards, cycle)
+	require.Equal(t, float64(0), a)
+}
+
+func TestConcurrentCacheGetReusable(t *testing.T) {
+	const count = 100_000
+	var c ConcurrentCache[int]
+	// Get распределяет элементы по шардам случайно, недостающие слабы шарды забирают друг у друга
+	cycle := func() {
+		for n := 0; n < count; n++ {
+			*c.Get() = n
+		}
+		c.Clear()
+	}
+	for n := 0; n < 5; n++ {
+		cycle()
+	}
+	a := testing.AllocsPerRun(10, cycle)
+	require.Equal(t, float64(0), a)
+}
+
+func TestConcurrentCacheReusableParallel(t *testing.T) {
+	const count = 100_000
+	var c ConcurrentCache[int]
+	workers := runtime.GOMAXPROCS(0)
+	var locals = make([]Local[int], workers)
+	for w := range locals {
+		locals[w] = c.Local()
+	}
+	cycle := func() {
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for _, l := range locals {
+			go func() {
+				defer wg.Done()
+				for n := 0; n < count; n++ {
+					*l.Get() = n
+				}
+			}()
+		}
+		wg.Wait()
+		c.Clear()
+	}
+	cycle()
+	var slabs = make([]int, cacheShards)
+	for k := range c.shards {
+		slabs[k] = len(c.shards[k].slabs)
+	}
+	for n := 0; n < 5; n++ {
+		cycle()
+	}
+	for k := range c.shards {
+		require.Equal(t, slabs[k], len(c.shards[k].slabs), "shard %d", k)
+	}
+}
+
+func BenchmarkConcurrentCache(b *testing.B) {
+	type T struct {
+		n int
+	}
+	for _, tc := range []struct {
+		name  string
+		count int
+	}{
+		{name: "1000", count: 1000},
+		{name: "10_000", count: 10_000},
+		{name: "1000_000", count: 1000_000},
+	} {
+		workers := runtime.GOMAXPROCS(0)
+		b.Run(tc.name+"/ConcurrentCache", func(b *testing.B) {
+			b.ReportAllocs()
+			var c ConcurrentCache[T]
+			for n := 0; n < b.N; n++ {
+				var wg sync.WaitGroup
+				wg.Add(workers)
+				for w := 0; w < workers; w++ {
+					go func() {
+						defer wg.Done()
+						l := c.Local()
+						for m := 0; m < tc.count/workers; m++ {
+							v := l.Get()
+							v.n = 1
+						}
+					}()
+				}
+				wg.Wait()
+				c.Clear()
+			}
+		})
+		b.Run(tc.name+"/ConcurrentCache.Get", func(b *testing.B) {
+			b.ReportAllocs()
+			var c ConcurrentCache[T]
+			for n := 0; n < b.N; n++ {
+				var wg sync.WaitGroup
+				wg.Add(workers)
+				for w := 0; w < workers; w++ {
+					go func() {
+						defer wg.Done()
+						for m := 0; m < tc.count/workers; m++ {
+							v := c.Get()
+							v.n = 1
+						}
+					}()
+				}
+				wg.Wait()
+				c.Clear()
+			}
+		})
+		b.Run(tc.name+"/sync.Pool", func(b *testing.B) {
+			b.ReportAllocs()
+			var p = sync.Pool{New: func() any { return new(T) }}
+			var got = make([][]*T, workers)
+			for n := 0; n < b.N; n++ {
+				var wg sync.WaitGroup
+				wg.Add(workers)
+				for w := 0; w < workers; w++ {
+					go func() {
+						defer wg.Done()
+						for m := 0; m < tc.count/workers; m++ {
+							v := p.Get().(*T)
+							v.n = 1
+							got[w] = append(got[w], v)
+						}
+						// аналог Clear: все полученные элементы возвращаются в пул в конце цикла
+						for _, v := range got[w] {
+							p.Put(v)
+						}
+						got[w] = got[w][:0]
+					}()
+				}
+				wg.Wait()
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+//go:build objcache_debug
+
+package objcache
+
+// slotDebug отмечает освобожденные ячейки, чтобы обнаружить повторный вызов Release.
+type slotDebug struct {
+	released bool
+}
+
+func (d *slotDebug) acquire() {
+	d.released = false
+}
+
+func (d *slotDebug) release() {
+	if d.released {
+		panic("objcache: element released twice")
+	}
+	d.released = true
+}
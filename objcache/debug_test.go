@@ -0,0 +1,30 @@
+//go:build objcache_debug
+
+package objcache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheDoubleRelease(t *testing.T) {
+	var c Cache[int]
+	v := c.Get()
+	c.Release(v)
+	require.PanicsWithValue(t, "objcache: element released twice", func() {
+		c.Release(v)
+	})
+
+	// после повторной выдачи элемент снова можно освободить, в том числе после Clear
+	c = Cache[int]{}
+	v = c.Get()
+	c.Release(v)
+	require.Same(t, v, c.Get())
+	c.Release(v)
+	c.Clear()
+	require.Same(t, v, c.Get())
+	require.NotPanics(t, func() {
+		c.Release(v)
+	})
+}
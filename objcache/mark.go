@@ -0,0 +1,34 @@
+package objcache
+
+// Mark контрольная точка кэша, возвращаемая Cache.Mark.
+type Mark[V any] struct {
+	used, i int
+	free    *slot[V]
+}
+
+// Mark открывает вложенную область и возвращает ее контрольную точку. Rollback с этой точкой освобождает все элементы,
+// выданные Get после вызова Mark, так что кэш работает как стековый аллокатор.
+// Внутри области Get повторно использует только элементы, освобожденные через Release в этой же области. Элементы, полученные
+// до Mark и освобожденные внутри области, после Rollback не выдаются до следующего Clear.
+func (c *Cache[V]) Mark() Mark[V] {
+	m := Mark[V]{used: c.used, i: c.i, free: c.free}
+	c.free = nil
+	return m
+}
+
+// Rollback закрывает область, открытую вызовом Mark, и освобождает все элементы, выданные Get после него, в том числе
+// во вложенных областях. После этого Get снова выдает те же адреса, что и после вызова Mark.
+// Контрольная точка перестает действовать после Clear и после Rollback к более ранней контрольной точке.
+func (c *Cache[V]) Rollback(m Mark[V]) {
+	if m.used > c.used || m.used == c.used && m.i > c.i {
+		panic("objcache: rollback to a mark that is no longer valid")
+	}
+	if c.policy == resetOnClear {
+		c.zero(m.used, m.i)
+	}
+	c.used, c.i, c.free = m.used, m.i, m.free
+	c.cur = nil
+	if c.used > 0 {
+		c.cur = c.slabs[c.used-1]
+	}
+}
@@ -0,0 +1,94 @@
+package objcache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheMark(t *testing.T) {
+	t.Run("nested", func(t *testing.T) {
+		var c Cache[int]
+		v0 := c.Get()
+		outer := c.Mark()
+		v1 := c.Get()
+		inner := c.Mark()
+		v2 := c.Get()
+		v3 := c.Get()
+
+		c.Rollback(inner)
+		require.Same(t, v2, c.Get())
+		require.Same(t, v3, c.Get())
+
+		c.Rollback(outer)
+		require.Same(t, v1, c.Get())
+		require.Same(t, v2, c.Get())
+		require.NotSame(t, v0, c.Get())
+	})
+	t.Run("across_slabs", func(t *testing.T) {
+		var c Cache[int]
+		c.Get()
+		m := c.Mark()
+		var v = make([]*int, 3*maxSlab)
+		for n := range v {
+			v[n] = c.Get()
+		}
+		for round := 0; round < 3; round++ {
+			c.Rollback(m)
+			for n := range v {
+				require.Same(t, v[n], c.Get())
+			}
+		}
+		c.Clear()
+		m = c.Mark()
+		c.Rollback(m)
+		require.Same(t, &c.slabs[0][0].v, c.Get())
+	})
+	t.Run("release", func(t *testing.T) {
+		var c Cache[int]
+		v0, v1 := c.Get(), c.Get()
+		c.Release(v0)
+
+		m := c.Mark()
+		// освобожденный до Mark элемент не используется внутри области
+		v2 := c.Get()
+		require.NotSame(t, v0, v2)
+		c.Release(v2)
+		require.Same(t, v2, c.Get())
+		c.Release(v1)
+		require.Same(t, v1, c.Get())
+		c.Release(v1)
+
+		c.Rollback(m)
+		require.Same(t, v0, c.Get())
+		require.Same(t, v2, c.Get())
+		require.NotSame(t, v1, c.Get())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var c Cache[int]
+		outer := c.Mark()
+		c.Get()
+		inner := c.Mark()
+		c.Get()
+		c.Rollback(outer)
+		require.Panics(t, func() {
+			c.Rollback(inner)
+		})
+	})
+}
+
+func TestCacheMarkNoAllocations(t *testing.T) {
+	var c Cache[int]
+	a := testing.AllocsPerRun(10, func() {
+		c.Clear()
+		for n := 0; n < 100; n++ {
+			c.Get()
+			m := c.Mark()
+			for k := 0; k < 1_000; k++ {
+				c.Get()
+			}
+			c.Rollback(m)
+		}
+	})
+	require.Equal(t, float64(0), a)
+}
@@ -0,0 +1,10 @@
+//go:build !objcache_debug
+
+package objcache
+
+// slotDebug не занимает памяти без тега objcache_debug, проверки повторного освобождения отключены.
+type slotDebug struct{}
+
+func (*slotDebug) acquire() {}
+
+func (*slotDebug) release() {}
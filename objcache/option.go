@@ -0,0 +1,54 @@
+package objcache
+
+// Option позволяет настроить кэш при его создании через New.
+type Option[V any] func(*options[V])
+
+// resetPolicy определяет, когда очищаются повторно выдаваемые элементы.
+type resetPolicy uint8
+
+const (
+	resetNone    resetPolicy = iota // элементы выдаются с содержимым, оставшимся от прошлого использования
+	resetOnGet                      // элемент обнуляется при выдаче из Get
+	resetOnClear                    // элементы обнуляются при освобождении через Clear, Rollback и Release
+	resetFunc                       // при выдаче из Get вызывается пользовательская функция
+)
+
+type options[V any] struct {
+	policy resetPolicy
+	reset  func(*V)
+}
+
+// ZeroOnGet обнуляет каждый элемент перед тем, как Get выдаст его. Стоимость очистки распределяется по вызовам Get
+// и затрагивает только действительно выдаваемые элементы.
+func ZeroOnGet[V any]() Option[V] {
+	return func(o *options[V]) {
+		o.policy, o.reset = resetOnGet, nil
+	}
+}
+
+// ZeroOnClear обнуляет элементы в момент их освобождения: Clear и Rollback очищают слабы целиком встроенной функцией clear,
+// Release обнуляет единственный элемент. Get при этом не тратит время на очистку.
+func ZeroOnClear[V any]() Option[V] {
+	return func(o *options[V]) {
+		o.policy, o.reset = resetOnClear, nil
+	}
+}
+
+// Reset задает функцию, которая приводит элемент в начальное состояние перед тем, как Get выдаст его.
+// Функция вызывается для каждого выдаваемого элемента, в том числе для нового, который еще ни разу не выдавался.
+func Reset[V any](fn func(*V)) Option[V] {
+	return func(o *options[V]) {
+		o.policy, o.reset = resetFunc, fn
+	}
+}
+
+// acquired подготавливает выдаваемый элемент согласно настройкам.
+func (o *options[V]) acquired(v *V) {
+	switch o.policy {
+	case resetOnGet:
+		var zero V
+		*v = zero
+	case resetFunc:
+		o.reset(v)
+	}
+}
@@ -0,0 +1,99 @@
+package objcache
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheResetPolicy(t *testing.T) {
+	type T struct {
+		n int
+		s []byte
+	}
+	t.Run("none", func(t *testing.T) {
+		var c = New[T]()
+		c.Get().n = 1
+		c.Clear()
+		require.Equal(t, 1, c.Get().n)
+	})
+	t.Run("zero_on_get", func(t *testing.T) {
+		var c = New(ZeroOnGet[T]())
+		v := c.Get()
+		*v = T{n: 1, s: []byte("foo")}
+		c.Release(v)
+		require.Equal(t, T{}, *c.Get())
+		c.Get().n = 2
+		c.Clear()
+		require.Equal(t, T{}, *c.Get())
+		require.Equal(t, T{}, *c.Get())
+	})
+	t.Run("zero_on_clear", func(t *testing.T) {
+		var c = New(ZeroOnClear[T]())
+		var v = make([]*T, 3*maxSlab)
+		for n := range v {
+			v[n] = c.Get()
+			*v[n] = T{n: n + 1}
+		}
+		c.Clear()
+		for n := range v {
+			require.Equal(t, T{}, *v[n], "element %d", n)
+		}
+
+		c.Get().n = 1
+		m := c.Mark()
+		for n := range v {
+			c.Get().n = n + 1
+		}
+		c.Rollback(m)
+		require.Equal(t, 1, v[0].n)
+		for n := 1; n < len(v); n++ {
+			require.Equal(t, T{}, *v[n], "element %d", n)
+		}
+
+		p := c.Get()
+		p.n = 3
+		c.Release(p)
+		require.Equal(t, T{}, *p)
+	})
+	t.Run("reset_func", func(t *testing.T) {
+		var c = New(Reset(func(v *T) {
+			v.n = -1
+			v.s = v.s[:0]
+		}))
+		v := c.Get()
+		require.Equal(t, -1, v.n)
+		v.n = 10
+		v.s = append(v.s, "foo"...)
+		c.Clear()
+
+		// буфер сохраняется и переиспользуется
+		require.Same(t, v, c.Get())
+		require.Equal(t, -1, v.n)
+		require.Empty(t, v.s)
+		require.GreaterOrEqual(t, cap(v.s), 3)
+	})
+	t.Run("sizeRestrictions", func(t *testing.T) {
+		require.Less(t, uint32(unsafe.Sizeof(*New(ZeroOnClear[int]()))), uint32(1024))
+	})
+}
+
+func TestCacheResetNoAllocations(t *testing.T) {
+	for name, opt := range map[string]Option[int]{
+		"zero_on_get":   ZeroOnGet[int](),
+		"zero_on_clear": ZeroOnClear[int](),
+		"reset_func":    Reset(func(v *int) { *v = 1 }),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var c = New(opt)
+			a := testing.AllocsPerRun(10, func() {
+				c.Clear()
+				for n := 0; n < 10_000; n++ {
+					*c.Get() = n
+				}
+			})
+			require.Equal(t, float64(0), a)
+		})
+	}
+}